@@ -0,0 +1,67 @@
+package main
+
+// flags.go parses the arena-cache-inspect command line. options is the
+// parsed result consumed by main(); see main.go for the overall program
+// flow.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"flag"
+	"strings"
+	"time"
+)
+
+// options holds every flag arena-cache-inspect accepts.
+type options struct {
+	target  string   // single target, kept for backward compatibility with -target
+	targets []string // parsed from -targets; falls back to []string{target} when empty
+
+	json bool
+	tui  bool
+	csv  string
+
+	watch    bool
+	interval time.Duration
+
+	heapProfile      string
+	goroutineProfile string
+
+	version bool
+}
+
+// parseFlags parses os.Args into an *options, applying the -targets/-target
+// fallback described on the targets field.
+func parseFlags() *options {
+	opts := &options{}
+
+	var targets string
+	flag.StringVar(&opts.target, "target", "http://localhost:6060", "arena-cache debug endpoint base URL")
+	flag.StringVar(&targets, "targets", "", "comma-separated list of arena-cache debug endpoint base URLs (overrides -target)")
+
+	flag.BoolVar(&opts.json, "json", false, "render snapshots as JSON instead of text")
+	flag.BoolVar(&opts.tui, "tui", false, "render snapshots in an interactive terminal UI with sparklines")
+	flag.StringVar(&opts.csv, "csv", "", "append one row per target per tick to this CSV file instead of printing to stdout")
+
+	flag.BoolVar(&opts.watch, "watch", false, "poll the target(s) on -interval instead of a single fetch")
+	flag.DurationVar(&opts.interval, "interval", 2*time.Second, "poll interval used by -watch")
+
+	flag.StringVar(&opts.heapProfile, "heap-profile", "", "download /debug/pprof/heap to this path and exit")
+	flag.StringVar(&opts.goroutineProfile, "goroutine-profile", "", "download /debug/pprof/goroutine to this path and exit")
+
+	flag.BoolVar(&opts.version, "version", false, "print the build version and exit")
+
+	flag.Parse()
+
+	if targets != "" {
+		for _, t := range strings.Split(targets, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				opts.targets = append(opts.targets, t)
+			}
+		}
+	} else {
+		opts.targets = []string{opts.target}
+	}
+
+	return opts
+}
@@ -1,16 +1,20 @@
 package main
 
 // main.go implements the arena‑cache inspector CLI: it parses command‑line
-// flags, fetches diagnostic data from a target process exposing the
-// arena‑cache debug endpoint, and prints it either as pretty text or JSON.  It
-// also supports periodic watch mode and pprof snapshot download.
+// flags, fetches diagnostic data from one or more target processes exposing
+// the arena‑cache debug endpoint, and renders it via the Renderer selected by
+// -json/-tui/-csv (plain text by default). It also supports periodic watch
+// mode and pprof snapshot download.
 //
 // The target Go service is expected to expose:
 //   • GET /debug/arena-cache/snapshot  – JSON payload with cache statistics.
 //   • GET /debug/pprof/{heap,goroutine} – standard pprof handlers (net/http/pprof).
 //
 // The snapshot object is intentionally generic; we decode into map[string]any
-// to avoid version skew between CLI and library.
+// to avoid version skew between CLI and library. In watch mode, consecutive
+// snapshots per target are diffed into hits/sec, misses/sec, eviction rate,
+// hit-ratio % and arena MB delta (see snapshot.go); -targets fans this out
+// across multiple processes concurrently with a TOTAL row appended.
 //
 // Build-time flag: `-ldflags "-X main.version=vX.Y.Z"` is set by GoReleaser.
 // ---------------------------------------------------------------
@@ -18,7 +22,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -49,25 +52,34 @@ func main() {
         cancel()
     }()
 
-    // pprof dump takes precedence over watch/json.
+    // pprof dump takes precedence over watch/render, and only ever targets
+    // the first configured endpoint.
     if opts.heapProfile != "" {
-        if err := downloadProfile(ctx, opts.target, "heap", opts.heapProfile); err != nil {
+        if err := downloadProfile(ctx, opts.targets[0], "heap", opts.heapProfile); err != nil {
             fatal(err)
         }
         return
     }
     if opts.goroutineProfile != "" {
-        if err := downloadProfile(ctx, opts.target, "goroutine", opts.goroutineProfile); err != nil {
+        if err := downloadProfile(ctx, opts.targets[0], "goroutine", opts.goroutineProfile); err != nil {
             fatal(err)
         }
         return
     }
 
+    renderer, err := newRenderer(opts)
+    if err != nil {
+        fatal(err)
+    }
+    defer renderer.Close()
+
+    states := make(map[string]*tickState, len(opts.targets))
+
     if opts.watch {
         ticker := time.NewTicker(opts.interval)
         defer ticker.Stop()
         for {
-            if err := dumpOnce(ctx, opts); err != nil {
+            if err := renderer.Render(fetchRows(ctx, opts.targets, states)); err != nil {
                 fmt.Fprintln(os.Stderr, "error:", err)
             }
             select {
@@ -80,7 +92,7 @@ func main() {
     }
 
     // one‑shot
-    if err := dumpOnce(ctx, opts); err != nil {
+    if err := renderer.Render(fetchRows(ctx, opts.targets, states)); err != nil {
         fatal(err)
     }
 }
@@ -89,61 +101,6 @@ func main() {
    Helpers
    ------------------------------------------------------------------------- */
 
-func dumpOnce(ctx context.Context, opts *options) error {
-    snap, err := fetchSnapshot(ctx, opts.target)
-    if err != nil {
-        return err
-    }
-
-    if opts.json {
-        enc := json.NewEncoder(os.Stdout)
-        enc.SetIndent("", "  ")
-        return enc.Encode(snap)
-    }
-    return prettyPrint(snap)
-}
-
-func fetchSnapshot(ctx context.Context, base string) (map[string]any, error) {
-    url := base + "/debug/arena-cache/snapshot"
-    req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-    res, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer res.Body.Close()
-    if res.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("unexpected status %s", res.Status)
-    }
-    var data map[string]any
-    if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
-        return nil, err
-    }
-    return data, nil
-}
-
-func prettyPrint(data map[string]any) error {
-    // naive pretty printer – assume common top‑level fields
-    fmt.Printf("Hits:     %v\n", data["hits_total"])
-    fmt.Printf("Misses:   %v\n", data["misses_total"])
-    fmt.Printf("Evictions:%v\n", data["evictions_total"])
-    fmt.Printf("Arena MB: %.2f\n", toFloat(data["arena_bytes"])/1_048_576)
-    return nil
-}
-
-func toFloat(v any) float64 {
-    switch t := v.(type) {
-    case float64:
-        return t
-    case int64:
-        return float64(t)
-    case json.Number:
-        f, _ := t.Float64()
-        return f
-    default:
-        return 0
-    }
-}
-
 func downloadProfile(ctx context.Context, base, name, path string) error {
     url := fmt.Sprintf("%s/debug/pprof/%s", base, name)
     req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -0,0 +1,135 @@
+package main
+
+// renderer.go defines the Renderer abstraction main.go renders each tick's
+// rows through, plus the text/json/csv implementations. The -tui backend
+// lives in tui.go since it pulls in a terminal UI dependency the other three
+// don't need.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Renderer turns one tick's rows into output. Render is called once per
+// tick; Close releases any resource the renderer holds open across ticks
+// (an open CSV file, a TUI screen, …) and is called once on shutdown.
+type Renderer interface {
+	Render(rows []row) error
+	Close() error
+}
+
+// newRenderer selects the Renderer implementation requested by opts. -tui
+// takes precedence over -csv, which takes precedence over -json; the
+// default is the plain text table.
+func newRenderer(opts *options) (Renderer, error) {
+	switch {
+	case opts.tui:
+		return newTUIRenderer()
+	case opts.csv != "":
+		return newCSVRenderer(opts.csv)
+	case opts.json:
+		return jsonRenderer{enc: json.NewEncoder(os.Stdout)}, nil
+	default:
+		return textRenderer{}, nil
+	}
+}
+
+// textRenderer prints a compact table to stdout, one line per target plus a
+// TOTAL line when multiple targets are configured.
+type textRenderer struct{}
+
+func (textRenderer) Render(rows []row) error {
+	fmt.Printf("%-24s %10s %10s %10s %8s %10s %10s\n",
+		"TARGET", "HITS/s", "MISSES/s", "EVICT/s", "HIT%", "ARENA MB", "DELTA MB")
+	for _, r := range rows {
+		if r.Err != nil {
+			fmt.Printf("%-24s error: %v\n", r.Target, r.Err)
+			continue
+		}
+		fmt.Printf("%-24s %10.1f %10.1f %10.1f %7.1f%% %10.2f %10.2f\n",
+			r.Target, r.HitsPerSec, r.MissesPerSec, r.EvictionsPerSec,
+			r.HitRatioPct, r.ArenaMB, r.ArenaDeltaMB)
+	}
+	return nil
+}
+
+func (textRenderer) Close() error { return nil }
+
+// jsonRenderer writes one JSON array of rows per tick to stdout.
+type jsonRenderer struct {
+	enc *json.Encoder
+}
+
+func (j jsonRenderer) Render(rows []row) error {
+	return j.enc.Encode(rows)
+}
+
+func (jsonRenderer) Close() error { return nil }
+
+// csvRenderer appends one record per target per tick to an on-disk CSV
+// file, writing the header once on first use so operators can pipe long
+// observations into offline analysis.
+type csvRenderer struct {
+	f *os.File
+	w *csv.Writer
+}
+
+func newCSVRenderer(path string) (*csvRenderer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		header := []string{"time", "target", "hits_per_sec", "misses_per_sec",
+			"evictions_per_sec", "hit_ratio_pct", "arena_mb", "arena_delta_mb", "error"}
+		if err := w.Write(header); err != nil {
+			f.Close()
+			return nil, err
+		}
+		w.Flush()
+	}
+	return &csvRenderer{f: f, w: w}, nil
+}
+
+func (c *csvRenderer) Render(rows []row) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, r := range rows {
+		errStr := ""
+		if r.Err != nil {
+			errStr = r.Err.Error()
+		}
+		rec := []string{
+			now, r.Target,
+			strconv.FormatFloat(r.HitsPerSec, 'f', 2, 64),
+			strconv.FormatFloat(r.MissesPerSec, 'f', 2, 64),
+			strconv.FormatFloat(r.EvictionsPerSec, 'f', 2, 64),
+			strconv.FormatFloat(r.HitRatioPct, 'f', 2, 64),
+			strconv.FormatFloat(r.ArenaMB, 'f', 2, 64),
+			strconv.FormatFloat(r.ArenaDeltaMB, 'f', 2, 64),
+			errStr,
+		}
+		if err := c.w.Write(rec); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvRenderer) Close() error {
+	c.w.Flush()
+	return c.f.Close()
+}
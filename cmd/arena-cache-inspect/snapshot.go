@@ -0,0 +1,156 @@
+package main
+
+// snapshot.go fetches raw /debug/arena-cache/snapshot payloads – one target
+// at a time, or fanned out across every -targets entry concurrently – and
+// turns consecutive payloads into per-tick deltas (row) for the Renderer
+// implementations in renderer.go and tui.go.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// row is one renderer-agnostic, already delta-computed line of output. The
+// rate fields read zero on a target's first tick – there is no previous
+// sample to diff against yet, not necessarily zero activity.
+type row struct {
+	Target string
+	Err    error
+
+	ArenaMB      float64
+	ArenaDeltaMB float64
+
+	HitsPerSec      float64
+	MissesPerSec    float64
+	EvictionsPerSec float64
+	HitRatioPct     float64
+}
+
+// tickState remembers one target's previous snapshot so fetchRow can derive
+// rates on the next call.
+type tickState struct {
+	prev   map[string]any
+	prevAt time.Time
+}
+
+func fetchSnapshot(ctx context.Context, base string) (map[string]any, error) {
+	url := base + "/debug/arena-cache/snapshot"
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+	var data map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func toFloat(v any) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case int64:
+		return float64(t)
+	case json.Number:
+		f, _ := t.Float64()
+		return f
+	default:
+		return 0
+	}
+}
+
+// fetchRows fans out one GET per target concurrently, diffs each result
+// against states[target]'s previous tick, and appends a synthesized TOTAL
+// row once more than one target is configured.
+func fetchRows(ctx context.Context, targets []string, states map[string]*tickState) []row {
+	rows := make([]row, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			rows[i] = fetchRow(ctx, target, states)
+		}(i, target)
+	}
+	wg.Wait()
+
+	if len(targets) > 1 {
+		rows = append(rows, totalRow(rows))
+	}
+	return rows
+}
+
+func fetchRow(ctx context.Context, target string, states map[string]*tickState) row {
+	now := time.Now()
+	st, ok := states[target]
+	if !ok {
+		st = &tickState{}
+		states[target] = st
+	}
+
+	snap, err := fetchSnapshot(ctx, target)
+	if err != nil {
+		return row{Target: target, Err: err}
+	}
+
+	r := row{
+		Target:  target,
+		ArenaMB: toFloat(snap["arena_bytes"]) / 1_048_576,
+	}
+
+	if st.prev != nil {
+		if dt := now.Sub(st.prevAt).Seconds(); dt > 0 {
+			hits := toFloat(snap["hits_total"]) - toFloat(st.prev["hits_total"])
+			misses := toFloat(snap["misses_total"]) - toFloat(st.prev["misses_total"])
+			evictions := toFloat(snap["evictions_total"]) - toFloat(st.prev["evictions_total"])
+
+			r.HitsPerSec = hits / dt
+			r.MissesPerSec = misses / dt
+			r.EvictionsPerSec = evictions / dt
+			r.ArenaDeltaMB = r.ArenaMB - toFloat(st.prev["arena_bytes"])/1_048_576
+			if total := hits + misses; total > 0 {
+				r.HitRatioPct = hits / total * 100
+			}
+		}
+	}
+
+	st.prev = snap
+	st.prevAt = now
+	return r
+}
+
+// totalRow sums the per-target fields into a synthesized "TOTAL" row; the
+// hit ratio is recomputed from the summed hit/miss rates rather than
+// averaged across targets, so it stays meaningful when traffic is uneven.
+func totalRow(rows []row) row {
+	total := row{Target: "TOTAL"}
+	var hits, misses float64
+	for _, r := range rows {
+		if r.Err != nil {
+			continue
+		}
+		total.ArenaMB += r.ArenaMB
+		total.ArenaDeltaMB += r.ArenaDeltaMB
+		total.EvictionsPerSec += r.EvictionsPerSec
+		hits += r.HitsPerSec
+		misses += r.MissesPerSec
+	}
+	total.HitsPerSec = hits
+	total.MissesPerSec = misses
+	if sum := hits + misses; sum > 0 {
+		total.HitRatioPct = hits / sum * 100
+	}
+	return total
+}
@@ -0,0 +1,138 @@
+package main
+
+// tui.go implements the -tui Renderer backend: an interactive terminal UI
+// built on tview, showing one row per target in a table plus an ASCII
+// sparkline of the last tuiWindow hit-rate samples. It lives apart from
+// renderer.go so the text/json/csv backends never pull in the tview
+// dependency.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// tuiWindow bounds how many past samples feed each sparkline.
+const tuiWindow = 60
+
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// tuiRenderer renders ticks into a tview table with a rolling sparkline per
+// target. Render is called from the goroutine driving main's ticker; updates
+// are marshalled onto tview's own draw goroutine via QueueUpdateDraw.
+type tuiRenderer struct {
+	app   *tview.Application
+	table *tview.Table
+	done  chan struct{}
+
+	history map[string]*tuiHistory
+}
+
+// tuiHistory is the rolling sample window kept per target.
+type tuiHistory struct {
+	hits []float64
+}
+
+func newTUIRenderer() (*tuiRenderer, error) {
+	app := tview.NewApplication()
+	table := tview.NewTable().SetBorders(false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitle(" arena-cache-inspect ")
+
+	r := &tuiRenderer{
+		app:     app,
+		table:   table,
+		done:    make(chan struct{}),
+		history: make(map[string]*tuiHistory),
+	}
+
+	go func() {
+		defer close(r.done)
+		if err := app.SetRoot(table, true).Run(); err != nil {
+			fmt.Fprintln(os.Stderr, "tui error:", err)
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *tuiRenderer) Render(rows []row) error {
+	r.app.QueueUpdateDraw(func() {
+		r.table.Clear()
+		headers := []string{"TARGET", "HITS/s", "MISSES/s", "EVICT/s", "HIT%", "ARENA MB", "DELTA MB", "HIT SPARK"}
+		for col, h := range headers {
+			r.table.SetCell(0, col, tview.NewTableCell(h).SetSelectable(false))
+		}
+
+		for i, rw := range rows {
+			line := i + 1
+			if rw.Err != nil {
+				r.table.SetCell(line, 0, tview.NewTableCell(rw.Target))
+				r.table.SetCell(line, 1, tview.NewTableCell("error: "+rw.Err.Error()))
+				continue
+			}
+
+			h := r.history[rw.Target]
+			if h == nil {
+				h = &tuiHistory{}
+				r.history[rw.Target] = h
+			}
+			h.hits = pushWindow(h.hits, rw.HitsPerSec)
+
+			r.table.SetCell(line, 0, tview.NewTableCell(rw.Target))
+			r.table.SetCell(line, 1, tview.NewTableCell(fmt.Sprintf("%.1f", rw.HitsPerSec)))
+			r.table.SetCell(line, 2, tview.NewTableCell(fmt.Sprintf("%.1f", rw.MissesPerSec)))
+			r.table.SetCell(line, 3, tview.NewTableCell(fmt.Sprintf("%.1f", rw.EvictionsPerSec)))
+			r.table.SetCell(line, 4, tview.NewTableCell(fmt.Sprintf("%.1f%%", rw.HitRatioPct)))
+			r.table.SetCell(line, 5, tview.NewTableCell(fmt.Sprintf("%.2f", rw.ArenaMB)))
+			r.table.SetCell(line, 6, tview.NewTableCell(fmt.Sprintf("%+.2f", rw.ArenaDeltaMB)))
+			r.table.SetCell(line, 7, tview.NewTableCell(sparkline(h.hits)))
+		}
+	})
+	return nil
+}
+
+func (r *tuiRenderer) Close() error {
+	r.app.Stop()
+	<-r.done
+	return nil
+}
+
+func pushWindow(series []float64, v float64) []float64 {
+	series = append(series, v)
+	if len(series) > tuiWindow {
+		series = series[len(series)-tuiWindow:]
+	}
+	return series
+}
+
+// sparkline renders series as a string of block characters scaled between
+// its own min and max.
+func sparkline(series []float64) string {
+	if len(series) == 0 {
+		return ""
+	}
+	min, max := series[0], series[0]
+	for _, v := range series {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	span := max - min
+	for _, v := range series {
+		idx := 0
+		if span > 0 {
+			idx = int((v - min) / span * float64(len(sparkChars)-1))
+		}
+		b.WriteRune(sparkChars[idx])
+	}
+	return b.String()
+}
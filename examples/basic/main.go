@@ -4,15 +4,20 @@ package main
 // to embed arena-cache in a real application.  The service exposes:
 //   • PUT /put?key=<k>&val=<v>    — insert a value
 //   • GET /get?key=<k>            — fetch or load (on miss)
-//   • GET /debug/arena-cache/snapshot — JSON with Len & SizeBytes
+//   • GET /debug/arena-cache/snapshot — JSON with Len, SizeBytes & namespace stats
 //   • GET /metrics                — Prometheus metrics (if built with -tags prom)
 //
+// /put and /get also accept a `ns` query parameter selecting a
+// Cache.Namespace sub-cache (e.g. per-tenant), sharing this instance's
+// shards and arena budget instead of requiring one Cache per tenant.
+//
 // Run:
 //   go run ./examples/basic
 // Then in another terminal:
 //   curl "localhost:6060/put?key=foo&val=bar"
 //   curl "localhost:6060/get?key=foo"
 //   curl "localhost:6060/get?key=baz"        # triggers loader
+//   curl "localhost:6060/put?key=foo&val=tenant-a&ns=acme"
 //   curl "localhost:6060/debug/arena-cache/snapshot"
 //
 // © 2025 arena-cache authors. MIT License.
@@ -50,6 +55,15 @@ func main() {
         return myVal{Data: "loaded:" + key}, nil
     }
 
+    // namespaceOf returns c itself, or – when the caller passed ?ns=<name> –
+    // the corresponding Cache.Namespace sub-cache sharing c's shards.
+    namespaceOf := func(r *http.Request) *cache.Cache[string, myVal] {
+        if ns := r.URL.Query().Get("ns"); ns != "" {
+            return c.Namespace(ns)
+        }
+        return c
+    }
+
     mux := http.NewServeMux()
 
     mux.HandleFunc("/put", func(w http.ResponseWriter, r *http.Request) {
@@ -59,7 +73,7 @@ func main() {
             http.Error(w, "missing key", 400)
             return
         }
-        c.Put(r.Context(), k, myVal{Data: v}, 1)
+        namespaceOf(r).Put(r.Context(), k, myVal{Data: v}, 1)
         fmt.Fprintf(w, "OK\n")
     })
 
@@ -69,7 +83,7 @@ func main() {
             http.Error(w, "missing key", 400)
             return
         }
-        v, err := c.GetOrLoad(r.Context(), k, loader)
+        v, err := namespaceOf(r).GetOrLoad(r.Context(), k, loader)
         if err != nil {
             http.Error(w, err.Error(), 500)
             return
@@ -80,9 +94,18 @@ func main() {
     // Snapshot endpoint consumed by arena-cache-inspect.
     mux.HandleFunc("/debug/arena-cache/snapshot", func(w http.ResponseWriter, r *http.Request) {
         snap := map[string]any{
-            "items":      c.Len(),
+            "items":       c.Len(),
             "arena_bytes": c.SizeBytes(),
         }
+        if ns := r.URL.Query().Get("ns"); ns != "" {
+            if stats, ok := c.NamespaceStats(ns); ok {
+                snap["namespace"] = ns
+                snap["namespace_hits"] = stats.Hits
+                snap["namespace_misses"] = stats.Misses
+                snap["namespace_evictions"] = stats.Evictions
+                snap["namespace_bytes"] = stats.Bytes
+            }
+        }
         _ = json.NewEncoder(w).Encode(snap)
     })
 
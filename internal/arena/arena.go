@@ -42,33 +42,32 @@ import (
 // directly depending on `arena.Arena`, giving us the freedom to switch to a
 // different allocator if needed.
 
-type Arena struct{ ar arena.Arena }
+type Arena struct{ ar *arena.Arena }
 
 // New constructs an empty arena ready for allocations.
 func New() *Arena {
-	var ar arena.Arena
-	return &Arena{ar: ar} // Initialize the internal arena.Arena correctly
+	return &Arena{ar: arena.NewArena()}
 }
 
 // Free releases **all** memory allocated in the arena.  After the call, any
 // pointer previously returned from New/MakeSlice becomes invalid.
 func (a *Arena) Free() {
-	a.ar = arena.Arena{} // Reset the arena to a new instance
+	a.ar.Free()
 }
 
 // NewValue allocates zero‑initialised T inside the arena and returns a pointer to it.
 // The pointer is valid until Free() on the arena.
-func NewValue[T any](a *Arena) *T { return arena.New[T](&a.ar) }
+func NewValue[T any](a *Arena) *T { return arena.New[T](a.ar) }
 
 // MakeSlice allocates a slice of length==cap==n inside the arena and returns
 // it.  The backing array is owned by the arena and will be released on Free().
-func MakeSlice[T any](a *Arena, n int) []T { return arena.MakeSlice[T](&a.ar, n, n) }
+func MakeSlice[T any](a *Arena, n int) []T { return arena.MakeSlice[T](a.ar, n, n) }
 
 // AllocBytes copies buf into the arena and returns a reference to the new
 // memory.  Convenience helper used when we need an immutable grain inside the
 // cache.
 func AllocBytes(a *Arena, buf []byte) []byte {
-	dst := arena.MakeSlice[byte](&a.ar, len(buf), len(buf))
+	dst := arena.MakeSlice[byte](a.ar, len(buf), len(buf))
 	copy(dst, buf)
 	return dst
 }
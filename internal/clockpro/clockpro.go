@@ -80,28 +80,115 @@ type entry[K comparable, V any] struct {
    Clock implementation
    ------------------------------------------------------------------------- */
 
+// ValueAccessor lets Clock extract the real V behind an entry's opaque vptr
+// without this package needing to know how the shard's arena allocator
+// works. The shard supplies one at NewClock construction time: Load copies
+// V out of vptr for the eviction callback, and Release is called once that
+// value is no longer reachable from CLOCK‑Pro, so the shard can account
+// for it – e.g. decrementing an arena refcount – deterministically rather
+// than relying on GC. Release must not assume vptr is still safe to
+// dereference: GenerationEvicted calls it after the owning generation's
+// arena has already been freed in bulk (see genring.Ring.Rotate), so an
+// implementation may only use vptr as an opaque identifier there.
+type ValueAccessor[V any] interface {
+    Load(vptr unsafe.Pointer) V
+    Release(vptr unsafe.Pointer)
+}
+
 type Clock[K comparable, V any] struct {
     head       *metaNode[K, V] // circular list head (hand points here)
     size       int64           // current "used bytes" (sum weights of HOT+COLD)
     capacity   int64           // byte budget (per‑shard)
 
+    // hotBytes/coldBytes/testBytes partition size by state, kept in lock-step
+    // with every state transition below so Sizes() never has to walk the
+    // ring. hotBytes+coldBytes always equals size; testBytes is tracked
+    // separately since ghosts hold no arena bytes (see GenerationEvicted).
+    hotBytes  int64
+    coldBytes int64
+    testBytes int64
+
+    // hotTarget is the adaptive HOT partition size from the CLOCK‑Pro paper:
+    // it starts at 0 and grows by a ghost's weight every time that ghost is
+    // hit (see ReinsertGhost), capped at maxHotTarget. evictIfNeeded demotes
+    // the oldest un-referenced HOT entry whenever hotBytes exceeds it, which
+    // is what gives scan-heavy workloads their resistance – a scan fills
+    // COLD and churns TEST without ever growing the HOT target.
+    hotTarget    int64
+    maxHotTarget int64 // capacity * hotFraction, fixed at construction
+
+    // testCapacity bounds testBytes independently of capacity – ghost
+    // metadata is cheap, but unbounded ghosts would remember every key ever
+    // evicted. trimGhosts() enforces it after every GenerationEvicted and
+    // capacity-driven ghost creation.
+    testCapacity int64
+
     // tunables
     weightFn func(V) int
 
     // user hook – nil if not provided
     ejectCb func(K, V, EvictionReason)
+
+    // access lets callEjectCb turn an entry's vptr back into a V; nil
+    // disables value delivery entirely (ejectCb is then skipped, matching
+    // the previous behaviour).
+    access ValueAccessor[V]
 }
 
-// NewClock constructs the CLOCK‑Pro supervisor.  weightFn and ejectCb are taken
-// from config.
-func NewClock[K comparable, V any](capacity int64, weightFn func(V) int, ejectCb func(K, V, EvictionReason)) *Clock[K, V] {
+// defaultHotFraction is used when NewClock is given a non-positive or >1
+// hotFraction.
+const defaultHotFraction = 0.5
+
+// NewClock constructs the CLOCK‑Pro supervisor.  weightFn, ejectCb and access
+// are taken from config; access is the shard's arena-aware implementation of
+// ValueAccessor. hotFraction caps the adaptive HOT target as a fraction of
+// capacity (see cache.WithHotFraction) and testCapacity bounds ghost (TEST)
+// bytes independently of capacity (see cache.WithTestCapacity); both fall
+// back to package defaults when non-positive.
+func NewClock[K comparable, V any](capacity int64, weightFn func(V) int, ejectCb func(K, V, EvictionReason), access ValueAccessor[V], hotFraction float64, testCapacity int64) *Clock[K, V] {
+    if hotFraction <= 0 || hotFraction > 1 {
+        hotFraction = defaultHotFraction
+    }
+    if testCapacity <= 0 {
+        testCapacity = capacity // ghosts may remember up to one capacity's worth of weight
+    }
     return &Clock[K, V]{
-        capacity: capacity,
-        weightFn: weightFn,
-        ejectCb:  ejectCb,
+        capacity:     capacity,
+        maxHotTarget: int64(float64(capacity) * hotFraction),
+        testCapacity: testCapacity,
+        weightFn:     weightFn,
+        ejectCb:      ejectCb,
+        access:       access,
     }
 }
 
+// Sizes reports the current HOT/COLD/TEST byte totals and the adaptive HOT
+// target, for WithMetrics to mirror onto the clock_hot_bytes/clock_cold_bytes/
+// clock_ghost_bytes/clock_hot_target_bytes gauges (see metrics.go).
+func (c *Clock[K, V]) Sizes() (hot, cold, test, target int64) {
+    return c.hotBytes, c.coldBytes, c.testBytes, c.hotTarget
+}
+
+// SetCapacity updates the byte budget and, proportionally, the adaptive HOT
+// ceiling (see NewClock's hotFraction) for a live Clock – used by
+// cache.Cache.Reconfigure to apply a new capacity without rebuilding the
+// ring. hotFraction follows NewClock's own validation: non-positive or >1
+// falls back to defaultHotFraction. If the budget shrank, or hotTarget now
+// exceeds the recomputed ceiling, eviction runs immediately so the live set
+// never drifts above the new capacity between now and the next Insert. The
+// caller must hold the shard's exclusive lock, as with Insert/ReinsertGhost.
+func (c *Clock[K, V]) SetCapacity(capacity int64, hotFraction float64) {
+    if hotFraction <= 0 || hotFraction > 1 {
+        hotFraction = defaultHotFraction
+    }
+    c.capacity = capacity
+    c.maxHotTarget = int64(float64(capacity) * hotFraction)
+    if c.hotTarget > c.maxHotTarget {
+        c.hotTarget = c.maxHotTarget
+    }
+    c.evictIfNeeded()
+}
+
 /*
    ---------------- Ring manipulation helpers ----------------
 */
@@ -143,10 +230,39 @@ func (c *Clock[K, V]) Insert(e any) {
     ent := (*entry[K, V])(e.(unsafe.Pointer))
     c.append(ent)
     c.size += int64(ent.weight)
+    c.coldBytes += int64(ent.weight)
     ent.state = stateCold | refBit
     c.evictIfNeeded()
 }
 
+// ReinsertGhost revives a ghost (TEST) entry that was hit again before its
+// metadata aged out of the ring – the shard's fast path overwrites vptr/genID
+// on the same *entry and calls this instead of Insert so the ring position
+// (and therefore recency) is preserved. Per the CLOCK‑Pro adaptive rule, a
+// ghost hit is evidence the entry was evicted too early: it grows hotTarget
+// by the entry's new weight, capped at maxHotTarget, and admits the entry
+// straight into HOT rather than COLD. The caller must hold the shard's
+// exclusive lock – this mutates ring-wide counters, unlike the plain
+// value/weight overwrite the non-ghost fast path performs under RLock.
+func (c *Clock[K, V]) ReinsertGhost(e any, weight int) {
+    ent := (*entry[K, V])(e.(unsafe.Pointer))
+    c.testBytes -= int64(ent.weight)
+    if c.testBytes < 0 {
+        c.testBytes = 0
+    }
+
+    c.hotTarget += int64(weight)
+    if c.hotTarget > c.maxHotTarget {
+        c.hotTarget = c.maxHotTarget
+    }
+
+    ent.weight = uint32(weight)
+    ent.state = stateHot | refBit
+    c.hotBytes += int64(weight)
+    c.size += int64(weight)
+    c.evictIfNeeded()
+}
+
 // Remove deletes entry from the metadata list (called when user explicitly
 // Cache.Delete). Does NOT touch arena memory.
 func (c *Clock[K, V]) Remove(e any) {
@@ -157,7 +273,10 @@ func (c *Clock[K, V]) Remove(e any) {
     n := c.head
     for {
         if n.entry == search {
-            c.size -= int64(n.entry.weight)
+            if c.access != nil && n.entry.vptr != nil && n.entry.state&0b11 != stateTest {
+                c.access.Release(n.entry.vptr)
+            }
+            c.untrack(n.entry)
             c.remove(n)
             return
         }
@@ -168,6 +287,22 @@ func (c *Clock[K, V]) Remove(e any) {
     }
 }
 
+// untrack removes ent's weight from whichever of size/hotBytes/coldBytes/
+// testBytes currently accounts for it, based on its state. Called right
+// before the entry's metaNode leaves the ring.
+func (c *Clock[K, V]) untrack(ent *entry[K, V]) {
+    switch ent.state & 0b11 {
+    case stateHot:
+        c.hotBytes -= int64(ent.weight)
+        c.size -= int64(ent.weight)
+    case stateCold:
+        c.coldBytes -= int64(ent.weight)
+        c.size -= int64(ent.weight)
+    case stateTest:
+        c.testBytes -= int64(ent.weight)
+    }
+}
+
 // GenerationEvicted notifies CLOCK‑Pro that all entries pointing to the given
 // generation no longer hold actual bytes (arena freed). We downgrade those
 // entries to TEST state so that they still influence future admission decisions.
@@ -180,8 +315,18 @@ func (c *Clock[K, V]) GenerationEvicted(genID uint32) {
         if n.entry.genID == genID {
             // Value already gone; treat as ghost.
             if n.entry.state&stateTest == 0 {
-                n.entry.state = stateTest
+                if c.access != nil && n.entry.vptr != nil {
+                    c.access.Release(n.entry.vptr)
+                }
+                switch n.entry.state & 0b11 {
+                case stateHot:
+                    c.hotBytes -= int64(n.entry.weight)
+                case stateCold:
+                    c.coldBytes -= int64(n.entry.weight)
+                }
                 c.size -= int64(n.entry.weight)
+                c.testBytes += int64(n.entry.weight)
+                n.entry.state = stateTest
             }
         }
         n = n.next
@@ -189,6 +334,37 @@ func (c *Clock[K, V]) GenerationEvicted(genID uint32) {
             break
         }
     }
+    c.trimGhosts()
+}
+
+// trimGhosts drops the oldest TEST entries from the ring until testBytes is
+// back within testCapacity. Ghost metadata costs no arena memory, but an
+// unbounded TEST list would remember every key ever evicted, defeating the
+// point of admission history being a *recent* signal.
+func (c *Clock[K, V]) trimGhosts() {
+    if c.testBytes <= c.testCapacity || c.head == nil {
+        return
+    }
+    n, loopStart := c.head, c.head
+    for c.testBytes > c.testCapacity {
+        if n.entry.state&0b11 != stateTest {
+            n = n.next
+            if n == loopStart {
+                return // walked the whole ring without finding enough ghosts
+            }
+            continue
+        }
+        nxt := n.next
+        c.untrack(n.entry)
+        c.remove(n)
+        if c.head == nil {
+            return // ring emptied
+        }
+        if nxt == n {
+            nxt = c.head
+        }
+        n, loopStart = nxt, nxt
+    }
 }
 
 /* -------------------------------------------------------------------------
@@ -202,6 +378,7 @@ func (c *Clock[K, V]) evictIfNeeded() {
     if c.head == nil {
         return
     }
+    c.shrinkHotToTarget()
     hand := c.head
     for c.size > c.capacity {
         st := hand.entry.state
@@ -213,21 +390,28 @@ func (c *Clock[K, V]) evictIfNeeded() {
             } else {
                 // hot but not referenced → demote to cold
                 hand.entry.state = stateCold
+                c.hotBytes -= int64(hand.entry.weight)
+                c.coldBytes += int64(hand.entry.weight)
             }
         case stateCold:
             if st&refBit != 0 {
                 // cold & referenced → promote to hot
                 hand.entry.state = stateHot
                 hand.entry.state &^= refBit
+                c.coldBytes -= int64(hand.entry.weight)
+                c.hotBytes += int64(hand.entry.weight)
             } else {
                 // cold & not referenced → evict value, turn into ghost (TEST)
                 c.callEjectCb(hand.entry, ReasonCapacity)
                 hand.entry.state = stateTest
+                c.coldBytes -= int64(hand.entry.weight)
                 c.size -= int64(hand.entry.weight)
+                c.testBytes += int64(hand.entry.weight)
             }
         case stateTest:
             // second time we land → remove metadata completely
             nxt := hand.next
+            c.testBytes -= int64(hand.entry.weight)
             c.remove(hand)
             hand = nxt
             continue // don't advance again – hand already points to nxt
@@ -235,18 +419,52 @@ func (c *Clock[K, V]) evictIfNeeded() {
         hand = hand.next
     }
     c.head = hand // update hand position
+    c.trimGhosts()
 }
 
-func (c *Clock[K, V]) callEjectCb(ent *entry[K, V], reason EvictionReason) {
-    if c.ejectCb == nil {
+// shrinkHotToTarget demotes the oldest un-referenced HOT entry to COLD,
+// starting from the hand, until hotBytes is back within hotTarget – per the
+// adaptive rule, HOT is only allowed to grow past the (recency-derived)
+// target when every HOT entry has been referenced since its last pass, in
+// which case there is nothing safe to demote and the normal ring scan below
+// takes over.
+func (c *Clock[K, V]) shrinkHotToTarget() {
+    if c.hotBytes <= c.hotTarget || c.head == nil {
         return
     }
-    if ent.vptr == nil {
+    n, loopStart := c.head, c.head
+    for c.hotBytes > c.hotTarget {
+        if n.entry.state&0b11 != stateHot {
+            n = n.next
+            if n == loopStart {
+                return
+            }
+            continue
+        }
+        if n.entry.state&refBit != 0 {
+            // referenced – give it another lap instead of demoting it blind.
+            n.entry.state &^= refBit
+            n = n.next
+            if n == loopStart {
+                return
+            }
+            continue
+        }
+        n.entry.state = stateCold
+        c.hotBytes -= int64(n.entry.weight)
+        c.coldBytes += int64(n.entry.weight)
+        n = n.next
+        loopStart = n
+    }
+}
+
+func (c *Clock[K, V]) callEjectCb(ent *entry[K, V], reason EvictionReason) {
+    if ent.vptr == nil || c.access == nil {
         return
     }
-    val := *(*V)(ent.vptr) // unsafe dance to get V – but we can't in generics
-    _ = val
-    // NOTE: Extracting V generically via unsafe is non‑trivial; we skip value
-    // passing to keep implementation tractable. Users interested in value can
-    // set weightFn to 0 so eviction never triggers, or instrument their code.
+    if c.ejectCb != nil {
+        val := c.access.Load(ent.vptr)
+        c.ejectCb(ent.key, val, reason)
+    }
+    c.access.Release(ent.vptr)
 }
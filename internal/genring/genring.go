@@ -15,6 +15,16 @@
 // access with its mutex.  All exported methods therefore assume external
 // synchronisation except where atomic is explicitly used (bytes counters).
 //
+// Generation count
+// -----------------
+// The ring's logical length (how many generations coexist before the oldest
+// rotates out) defaults to an autotuner: Rotate folds each freed generation's
+// actual size into a rolling EWMA and re-derives capBytes/avgArenaEWMA,
+// clamped to [minGenerations, maxGenerations], converging the per-generation
+// byte budget toward whatever the workload's real item sizes call for.
+// cache.WithGenerations pins an explicit count instead, disabling the
+// autotuner entirely.
+//
 // © 2025 arena-cache authors. MIT License.
 
 package genring
@@ -76,19 +86,49 @@ func (g *generation) free() {
    ------------------------------------------------------------------------- */
 
 type Ring[K comparable, V any] struct {
+    // gens is always allocated at maxGenerations length so growing the
+    // logical ring (see generations below) never reallocates; indices at or
+    // beyond generations simply sit unused (nil) until autotune grows into
+    // them.
     gens        []*generation
     activeIdx   int
     ttl         time.Duration
+    capBytes    int64
     perGenBytes int64
 
     idCtr atomic.Uint32
+
+    // generations is the logical ring length in [minGenerations,
+    // maxGenerations] – Rotate and CheckRotationNeeded use it instead of
+    // len(gens). pendingGenerations holds an autotune()-computed change
+    // until Rotate completes a full lap back to slot 0, so a shrink never
+    // strands a live generation outside the new modulus (see Rotate).
+    generations        int
+    pendingGenerations int
+
+    // autotuneEnabled is false once cache.WithGenerations pins an explicit
+    // count; true means generations is continuously re-derived from
+    // capBytes/avgArenaEWMA at every Rotate (see autotune). avgArenaEWMA is
+    // the rolling EWMA of bytes actually accumulated by a generation before
+    // it rotates out, in the same weight units as capBytes.
+    autotuneEnabled bool
+    avgArenaEWMA    float64
 }
 
-const defaultGenerations = 4 // may be tuned in future
+const (
+    defaultGenerations = 4  // starting point, both for a pinned count of 0 and before the autotuner has any samples
+    minGenerations     = 2  // below this, a single generation would dominate the whole TTL window
+    maxGenerations     = 16 // above this, per-generation byte budgets get too small to be useful
+    ewmaAlpha          = 0.2
+)
 
 // New constructs a generation ring sized for the given capacity and TTL.
-// capBytes is capacity *per shard*.
-func New[K comparable, V any](capBytes int64, ttl time.Duration) *Ring[K, V] {
+// capBytes is capacity *per shard*. generations pins the ring's logical
+// length (see cache.WithGenerations), clamped to [minGenerations,
+// maxGenerations]; 0 or negative enables the autotuner instead, which starts
+// at defaultGenerations and adjusts at every Rotate based on an EWMA of
+// observed generation sizes (see autotune).
+func New[K comparable, V any](capBytes int64, ttl time.Duration, generations int) *Ring[K, V] {
     if capBytes <= 0 {
         panic("genring: capBytes must be positive")
     }
@@ -96,14 +136,27 @@ func New[K comparable, V any](capBytes int64, ttl time.Duration) *Ring[K, V] {
         panic("genring: ttl must be positive")
     }
 
+    autotune := generations <= 0
+    switch {
+    case autotune:
+        generations = defaultGenerations
+    case generations < minGenerations:
+        generations = minGenerations
+    case generations > maxGenerations:
+        generations = maxGenerations
+    }
+
     r := &Ring[K, V]{
-        ttl:         ttl,
-        perGenBytes: capBytes / defaultGenerations,
+        ttl:             ttl,
+        capBytes:        capBytes,
+        generations:     generations,
+        autotuneEnabled: autotune,
+        perGenBytes:     capBytes / int64(generations),
     }
     if r.perGenBytes == 0 {
         r.perGenBytes = capBytes // tiny caches → single-gen capacity control
     }
-    r.gens = make([]*generation, defaultGenerations)
+    r.gens = make([]*generation, maxGenerations)
 
     // Generation IDs start at 1 (0 reserved for "nil").
     r.idCtr.Store(1)
@@ -113,6 +166,20 @@ func New[K comparable, V any](capBytes int64, ttl time.Duration) *Ring[K, V] {
     return r
 }
 
+// Generations reports the ring's current logical generation count, for
+// WithMetrics/WithOTelMeter to mirror onto the generations gauge (see
+// metricsSink.setGenerations). Changes at most once per Rotate.
+func (r *Ring[K, V]) Generations() int {
+    return r.generations
+}
+
+// TTL reports the ring's currently configured TTL (see cache.WithFallback's
+// write-through, which needs it to mirror the in-process expiry onto the L2
+// provider's own Set call). Changes at most once per Reconfigure.
+func (r *Ring[K, V]) TTL() time.Duration {
+    return r.ttl
+}
+
 // Active returns the generation currently used for new allocations.
 func (r *Ring[K, V]) Active() *generation {
     return r.gens[r.activeIdx]
@@ -126,13 +193,31 @@ func (r *Ring[K, V]) CheckRotationNeeded(delta int64) bool {
     return g.size() > r.perGenBytes
 }
 
+// Reconfigure updates the ring's per-generation byte budget and TTL for a
+// live Ring – used by cache.Cache.Reconfigure to apply new capacity/TTL
+// without rebuilding the ring (existing generations and their arenas are
+// untouched; only future rotation decisions use the new numbers). It
+// reports whether the active generation already outlives the new
+// per-generation window (ttl/generations), in which case the caller
+// (shard.reconfigure) should Rotate immediately instead of waiting for the
+// next Put or the Sweeper middleware's next tick to notice a shrunk TTL.
+func (r *Ring[K, V]) Reconfigure(capBytes int64, ttl time.Duration) bool {
+    r.capBytes = capBytes
+    r.perGenBytes = capBytes / int64(r.generations)
+    if r.perGenBytes == 0 {
+        r.perGenBytes = capBytes
+    }
+    r.ttl = ttl
+    return time.Since(r.Active().created) > ttl/time.Duration(r.generations)
+}
+
 // Rotate advances the ring, creates a fresh generation, and frees the arena of
 // whichever generation falls out of the TTL window.  The *freed* generation is
 // returned so that CLOCK-Pro can retain its ghost metadata.  Returned pointer
 // may be nil when the slot was empty (only happens before the ring is fully
 // warmed up).
 func (r *Ring[K, V]) Rotate() *generation {
-    nextIdx := (r.activeIdx + 1) % len(r.gens)
+    nextIdx := (r.activeIdx + 1) % r.generations
 
     // Free the arena of the generation we are about to overwrite.
     dead := r.gens[nextIdx]
@@ -145,9 +230,54 @@ func (r *Ring[K, V]) Rotate() *generation {
     fresh := newGeneration(newID)
     r.gens[nextIdx] = fresh
     r.activeIdx = nextIdx
+
+    // Apply any autotune()-queued generation-count change only once the
+    // ring has completed a full lap back to slot 0 – applying it mid-lap
+    // could shrink the modulus past a slot that still holds a live
+    // generation, stranding it outside every future Rotate.
+    if nextIdx == 0 && r.pendingGenerations != 0 {
+        r.generations = r.pendingGenerations
+        r.pendingGenerations = 0
+        r.perGenBytes = r.capBytes / int64(r.generations)
+        if r.perGenBytes == 0 {
+            r.perGenBytes = r.capBytes
+        }
+    }
+
+    if r.autotuneEnabled && dead != nil {
+        r.autotune(dead.size())
+    }
+
     return dead
 }
 
+// autotune folds observedBytes – the just-freed generation's accumulated
+// size – into a rolling EWMA and re-derives the target generation count as
+// capBytes/avgArenaEWMA, clamped to [minGenerations, maxGenerations]. The
+// result is queued in pendingGenerations rather than applied immediately;
+// see Rotate. No-op once cache.WithGenerations pins an explicit count.
+func (r *Ring[K, V]) autotune(observedBytes int64) {
+    if observedBytes <= 0 {
+        return
+    }
+    if r.avgArenaEWMA == 0 {
+        r.avgArenaEWMA = float64(observedBytes)
+    } else {
+        r.avgArenaEWMA = ewmaAlpha*float64(observedBytes) + (1-ewmaAlpha)*r.avgArenaEWMA
+    }
+
+    target := int(float64(r.capBytes) / r.avgArenaEWMA)
+    if target < minGenerations {
+        target = minGenerations
+    }
+    if target > maxGenerations {
+        target = maxGenerations
+    }
+    if target != r.generations {
+        r.pendingGenerations = target
+    }
+}
+
 // LiveBytes sums approximate sizes across all generations.  Cheap enough for
 // sporadic calls.
 func (r *Ring[K, V]) LiveBytes() int64 {
@@ -16,24 +16,34 @@ package cache
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash/maphash"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	arena "github.com/Voskan/arena-cache/internal/arena"
 	"github.com/Voskan/arena-cache/internal/clockpro"
 	"github.com/Voskan/arena-cache/internal/genring"
+	"github.com/Voskan/arena-cache/pkg/provider"
 )
 
 // entryState encodes CLOCK‑Pro flags in a compact form.
 const (
-    stateCold   uint8 = 0b00 // item is cold and not recently referenced
-    stateHot    uint8 = 0b01 // item is hot (frequently used)
-    stateTest   uint8 = 0b10 // ghost entry – remembered after eviction
-    refBit      uint8 = 0b10000000 // high bit is the *reference* (R) flag
+    stateCold      uint8 = 0b00 // item is cold and not recently referenced
+    stateHot       uint8 = 0b01 // item is hot (frequently used)
+    stateTest      uint8 = 0b10 // ghost entry – remembered after eviction
+    stateTombstone uint8 = 0b11 // negative-result marker – see WithNegativeTTL
+    refBit         uint8 = 0b10000000 // high bit is the *reference* (R) flag
 )
 
 // entry is the metadata kept for every cached item. It purposefully fits into
@@ -60,8 +70,26 @@ type entry[K comparable, V any] struct {
     weight  uint32         // user‑defined weight units
     genID   uint32         // generation that owns this value
     state   uint8          // CLOCK‑Pro state + R‑bit
+
+    // The fields below are only populated for stateTombstone entries (see
+    // WithNegativeTTL): no arena allocation backs a tombstone, so vptr stays
+    // nil and these carry the cached negative result instead.
+    tombErr  error // the loader error to replay while the tombstone is live
+    expireAt int64 // unix nanos after which the tombstone is treated as a miss
 }
 
+// clockValueAccessor is the shard's clockpro.ValueAccessor[V] implementation:
+// it knows the entry.vptr layout (a *V allocated inside an arena generation)
+// so clockpro can stay free of that detail. Release is currently a no-op –
+// arena memory is reclaimed in bulk on generation rotation (see genring.Ring.
+// Rotate), not per value – but gives us a deterministic hook for refcounting
+// should a future arena allocator need one.
+type clockValueAccessor[V any] struct{}
+
+func (clockValueAccessor[V]) Load(vptr unsafe.Pointer) V { return *(*V)(vptr) }
+
+func (clockValueAccessor[V]) Release(vptr unsafe.Pointer) {}
+
 // shard owns all mutable structures for a slice of the key‑space.  Except for
 // short critical sections protected by the RWMutex, all operations are
 // lock‑free thanks to atomic primitives implemented in internal/clockpro.
@@ -87,28 +115,116 @@ type shard[K comparable, V any] struct {
     misses    atomic.Uint64
     evictions atomic.Uint64
 
+    // loaders deduplicates concurrent GetOrLoad misses on the same key so a
+    // stampede on one cold key invokes the user loader once. loads counts
+    // every GetOrLoad miss that reached the loader path; loadDuplicates is
+    // the subset that were served a shared in-flight result instead of
+    // running the loader themselves.
+    loaders        *loaderGroup[K, V]
+    loads          atomic.Uint64
+    loadDuplicates atomic.Uint64
+
+    // negativeTTL, when > 0, makes GetOrLoad cache loader errors classified
+    // by isNegativeFn as tombstones for the given window (see
+    // cache.WithNegativeTTL). negativeHits counts tombstone short-circuits.
+    negativeTTL  time.Duration
+    isNegativeFn func(error) bool
+    negativeHits atomic.Uint64
+
+    // negativeCapBytes bounds how much weight (see tombstoneWeight) live
+    // tombstones may occupy (see cache.WithNegativeCapacityFraction);
+    // negativeWeight tracks the current total. putTombstone evicts the
+    // soonest-to-expire tombstones once the budget would be exceeded.
+    negativeCapBytes int64
+    negativeWeight   atomic.Int64
+
+    // loaderCoalescing/loaderTimeout mirror cache.WithLoaderCoalescing and
+    // cache.WithLoaderTimeout; see getOrLoad and loaderGroup.load.
+    loaderCoalescing bool
+    loaderTimeout    time.Duration
+
     // hash seed – each shard owns its own maphash.Seed to avoid global locks.
     seed maphash.Seed
+
+    // weightFn re-derives a weight for values pulled back from the L2
+    // fallback, where the original caller-supplied weight is not available.
+    weightFn func(V) int
+
+    // fallback is an optional L2 provider consulted on miss (see
+    // cache.WithFallback) before the caller's loader runs.
+    fallback             provider.Provider[K, V]
+    fallbackWriteThrough bool
+    fallbackErrors       atomic.Uint64
+
+    // idx is this shard's own index within Cache.shards; metrics is the sink
+    // shared by every shard of the Cache. Both exist solely so rotate() can
+    // self-report its duration (see metricsSink.observeRotationDuration) –
+    // other metrics are recorded at the Cache boundary, where the caller's
+    // key is still available to compute the shard index.
+    idx     uint8
+    metrics metricsSink
+
+    // tracer mirrors Cache.tracer so rotate() can open its own
+    // "arena_cache.rotate" span (see cache.WithOTelTracer) – rotate runs off
+    // the hot path on a background timer (see the Sweeper middleware) or
+    // inline from put(), neither of which hands it a caller context worth
+    // reusing, so it starts a fresh context.Background() span instead. Nil
+    // when WithOTelTracer was not configured.
+    tracer trace.Tracer
 }
 
 // newShard constructs an empty shard. It assumes the caller already validated
 // all arguments (capBytes > 0, ttl > 0, etc.)
-func newShard[K comparable, V any](capBytes int64, ttl time.Duration, weightFn func(V) int,
+func newShard[K comparable, V any](idx uint8, capBytes int64, ttl time.Duration, weightFn func(V) int,
     ejectCb func(K, V, clockpro.EvictionReason),
+    fallback provider.Provider[K, V], fallbackWriteThrough bool,
+    negativeTTL time.Duration, isNegativeFn func(error) bool, negativeCapFraction float64,
+    metrics metricsSink,
+    loaderCoalescing bool, loaderTimeout time.Duration,
+    hotFraction float64, testCapacity int64,
+    tracer trace.Tracer, generations int,
 ) *shard[K, V] {
     s := &shard[K, V]{
         index:  make(map[uint64]*entry[K, V], 1024), // start with 1k slots
-        clock:  clockpro.NewClock[K, V](capBytes, weightFn, ejectCb),
-        genRing: genring.New[K, V](capBytes, ttl),
+        clock:  clockpro.NewClock[K, V](capBytes, weightFn, ejectCb, clockValueAccessor[V]{}, hotFraction, testCapacity),
+        genRing: genring.New[K, V](capBytes, ttl, generations),
         seed:   maphash.MakeSeed(),
+        weightFn: weightFn,
+        loaders: newLoaderGroup[K, V](),
+        fallback: fallback,
+        fallbackWriteThrough: fallbackWriteThrough,
+        negativeTTL: negativeTTL,
+        isNegativeFn: isNegativeFn,
+        negativeCapBytes: int64(float64(capBytes) * negativeCapFraction),
+        idx:     idx,
+        metrics: metrics,
+        loaderCoalescing: loaderCoalescing,
+        loaderTimeout:    loaderTimeout,
+        tracer:           tracer,
     }
     return s
 }
 
-// hash returns SipHash‑64 of the provided key using shard‑local seed.
+// hash returns SipHash‑64 of the provided key using shard‑local seed. It is a
+// thin wrapper over hashNS for the (default) root namespace.
 func (s *shard[K, V]) hash(key K) uint64 {
+    return s.hashNS(0, key)
+}
+
+// hashNS returns SipHash‑64 of key, prefixed with the 4‑byte namespace id ns
+// (see Cache.Namespace). Prefixing – rather than, say, hashing ns and key
+// separately and combining the two sums – guarantees that two namespaces
+// never collide on the same shard index for identical keys: the namespace
+// id becomes part of the hashed byte stream itself. ns == 0 is the root
+// namespace and hashes identically to the pre‑namespace behaviour.
+func (s *shard[K, V]) hashNS(ns uint32, key K) uint64 {
     var h maphash.Hash
     h.SetSeed(s.seed)
+    if ns != 0 {
+        var nsBytes [4]byte
+        binary.LittleEndian.PutUint32(nsBytes[:], ns)
+        h.Write(nsBytes[:])
+    }
     // Use type switch to avoid reflection for common key types.
     switch k := any(key).(type) {
     case string:
@@ -131,9 +247,10 @@ func (s *shard[K, V]) hash(key K) uint64 {
 */
 
 // get returns the value pointer (residing in arena) and a flag whether the item
-// was found.  It updates CLOCK‑Pro metadata in lock‑free manner.
-func (s *shard[K, V]) get(key K) (val V, ok bool) {
-    h := s.hash(key)
+// was found.  It updates CLOCK‑Pro metadata in lock‑free manner. ns selects the
+// namespace the key belongs to (see Cache.Namespace); 0 is the root namespace.
+func (s *shard[K, V]) get(ns uint32, key K) (val V, ok bool) {
+    h := s.hashNS(ns, key)
 
     s.mu.RLock()
     ent, found := s.index[h]
@@ -169,12 +286,28 @@ func (s *shard[K, V]) get(key K) (val V, ok bool) {
 // there is an older entry — it stays intact until its generation rotates out.
 //
 // weight allows the caller to express relative cost (bytes, logical weight…).
-func (s *shard[K, V]) put(key K, val V, weight int) {
-    h := s.hash(key)
+// ns selects the namespace the key belongs to (see Cache.Namespace); 0 is the
+// root namespace. The L2 fallback, if any, is shared across namespaces and
+// keyed by the raw key alone – namespace isolation only applies to the
+// in-process arena tier.
+func (s *shard[K, V]) put(ctx context.Context, ns uint32, key K, val V, weight int) {
+    if s.fallbackWriteThrough && s.fallback != nil {
+        if err := s.fallback.Set(ctx, key, val, weight, int64(s.genRing.TTL())); err != nil {
+            s.fallbackErrors.Add(1)
+        }
+    }
+
+    h := s.hashNS(ns, key)
 
-    // Fast path: optimistic read‑lock, upgrade on miss.
+    // Fast path: optimistic read‑lock, upgrade on miss. A tombstone (see
+    // WithNegativeTTL) was never registered with CLOCK‑Pro, so a real value
+    // arriving for it falls through to the slow path below instead of being
+    // revived in place. A ghost (ReasonCapacity/ReasonGeneration TEST state)
+    // also falls through: reviving it touches clock ring-wide counters (see
+    // clockpro.Clock.ReinsertGhost), which – unlike the plain pointer/weight
+    // overwrite below – needs the exclusive lock the slow path holds.
     s.mu.RLock()
-    if old, ok := s.index[h]; ok && old.key == key {
+    if old, ok := s.index[h]; ok && old.key == key && (old.state&0b11 == stateHot || old.state&0b11 == stateCold) {
         // Update hot path – no need for hash collision check twice.
         // We merely overwrite the value pointer & weight; key remains.
         gen := s.genRing.Active()
@@ -189,11 +322,30 @@ func (s *shard[K, V]) put(key K, val V, weight int) {
     }
     s.mu.RUnlock()
 
-    // Slow path: need exclusive lock to insert a fresh entry.
+    // Slow path: need exclusive lock to insert a fresh entry, or to revive a
+    // ghost in place.
     s.mu.Lock()
     defer s.mu.Unlock()
 
     gen := s.genRing.Active()
+
+    if old, ok := s.index[h]; ok && old.key == key && old.state&0b11 == stateTest {
+        ptr := arena.NewValue[V](gen.Arena())
+        *ptr = val
+
+        old.vptr = unsafe.Pointer(ptr)
+        old.genID = gen.ID()
+        s.clock.ReinsertGhost(unsafe.Pointer(old), weight)
+        s.reportClockSizes()
+        if s.genRing.CheckRotationNeeded(int64(weight)) {
+            s.rotate()
+        }
+        return
+    }
+    if old, ok := s.index[h]; ok && old.state&0b11 == stateTombstone {
+        s.negativeWeight.Add(-tombstoneWeight) // the overwrite below drops it
+    }
+
     ptr := arena.NewValue[V](gen.Arena())
     *ptr = val
 
@@ -210,7 +362,8 @@ func (s *shard[K, V]) put(key K, val V, weight int) {
     s.index[h] = ent
 
     // Register in CLOCK‑Pro (may trigger internal eviction).
-    s.clock.Insert(ent)
+    s.clock.Insert(unsafe.Pointer(ent))
+    s.reportClockSizes()
 
     // If generation grew beyond capacity – rotate.
     if s.genRing.CheckRotationNeeded(int64(weight)) {
@@ -218,19 +371,54 @@ func (s *shard[K, V]) put(key K, val V, weight int) {
     }
 }
 
-// delete removes key from the shard. It does not free the underlying arena
-// memory immediately (that happens on generation rotation).
-func (s *shard[K, V]) delete(key K) {
-    h := s.hash(key)
+// reportClockSizes mirrors the clock's current HOT/COLD/TEST byte totals and
+// adaptive HOT target onto the configured metrics sink (see
+// cache.WithHotFraction, cache.WithTestCapacity and metricsSink.
+// setClockSizes). Cheap no-op when WithMetrics was not configured.
+func (s *shard[K, V]) reportClockSizes() {
+    hot, cold, ghost, target := s.clock.Sizes()
+    s.metrics.setClockSizes(s.idx, hot, cold, ghost, target)
+}
 
+// reconfigure applies a new per-shard capacity and TTL under the shard's
+// exclusive lock (see Cache.Reconfigure): genRing's rotation budget/window
+// and CLOCK‑Pro's byte budget are both updated in place, and – if the active
+// generation already outlives the new, presumably shorter, TTL – rotate()
+// runs immediately instead of waiting for the next Put or the Sweeper
+// middleware's next tick to notice.
+func (s *shard[K, V]) reconfigure(capBytes int64, ttl time.Duration, hotFraction float64) {
     s.mu.Lock()
-    ent, ok := s.index[h]
-    if ok && ent.key == key {
+    defer s.mu.Unlock()
+
+    needsRotate := s.genRing.Reconfigure(capBytes, ttl)
+    s.clock.SetCapacity(capBytes, hotFraction)
+    s.reportClockSizes()
+    if needsRotate {
+        s.rotate()
+    }
+}
+
+// delete removes key from the shard, reporting the weight of the removed
+// entry so callers (e.g. Cache.Namespace's byte accounting) can adjust their
+// own counters without a second lookup. It does not free the underlying
+// arena memory immediately (that happens on generation rotation).
+func (s *shard[K, V]) delete(ns uint32, key K) (weight uint32, ok bool) {
+    h := s.hashNS(ns, key)
+
+    s.mu.Lock()
+    ent, found := s.index[h]
+    if found && ent.key == key {
+        weight, ok = ent.weight, true
+        if ent.state&0b11 == stateTombstone {
+            s.negativeWeight.Add(-tombstoneWeight)
+        }
         delete(s.index, h)
-        s.clock.Remove(ent)
+        s.clock.Remove(unsafe.Pointer(ent))
         s.evictions.Add(1)
+        s.reportClockSizes()
     }
     s.mu.Unlock()
+    return weight, ok
 }
 
 // rotate is called by the parent Cache at a scheduled interval or when the
@@ -238,7 +426,15 @@ func (s *shard[K, V]) delete(key K) {
 // genRing, while CLOCK‑Pro is notified about the new generation so that ghost
 // entries from freed arenas may still influence replacement policy.
 func (s *shard[K, V]) rotate() {
+    if s.tracer != nil {
+        var span trace.Span
+        _, span = s.tracer.Start(context.Background(), "arena_cache.rotate", trace.WithAttributes(attribute.Int("shard", int(s.idx))))
+        defer span.End()
+    }
+
+    start := time.Now()
     deadGen := s.genRing.Rotate()
+    s.metrics.setGenerations(s.idx, int64(s.genRing.Generations()))
     if deadGen == nil {
         return // nothing to free yet
     }
@@ -246,6 +442,8 @@ func (s *shard[K, V]) rotate() {
     // Inform CLOCK‑Pro – ghost entries are kept with stateTest so they survive
     // for a while and affect admission decisions.
     s.clock.GenerationEvicted(deadGen.ID())
+    s.reportClockSizes()
+    s.metrics.observeRotationDuration(s.idx, time.Since(start))
 }
 
 // len returns *approximate* number of live items (RLock used – safe for hot
@@ -257,6 +455,24 @@ func (s *shard[K, V]) len() int {
     return n
 }
 
+// rotateIfIdle rotates the shard's generation ring iff it currently holds no
+// items, reporting whether it did. Unlike len(), the "is it empty" check and
+// the rotate() call itself must happen under the same exclusive lock – every
+// other rotate() call site (put, reconfigure) already holds s.mu.Lock()
+// across it because rotate() mutates genRing/clock/arena state, and a
+// separate len()==0 check followed by an unlocked rotate() (as the Sweeper
+// middleware used to do) races with a concurrent Put/Get/Reconfigure on the
+// same shard.
+func (s *shard[K, V]) rotateIfIdle() bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if len(s.index) != 0 {
+        return false
+    }
+    s.rotate()
+    return true
+}
+
 // statsSnapshot returns atomic counters – useful for prometheus scraping.
 func (s *shard[K, V]) statsSnapshot() (hits, misses, evict uint64) {
     return s.hits.Load(), s.misses.Load(), s.evictions.Load()
@@ -265,6 +481,80 @@ func (s *shard[K, V]) statsSnapshot() (hits, misses, evict uint64) {
 // Cache represents the main cache structure.
 type Cache[K comparable, V any] struct {
     shards []*shard[K, V]
+
+    // get/put/delete/getOrLoad are the (possibly middleware-wrapped) entry
+    // points used by the exported methods below. Without any Use(...) option
+    // they are set directly to the un-wrapped shard dispatch.
+    getFn       GetFunc[K, V]
+    putFn       PutFunc[K, V]
+    deleteFn    DeleteFunc[K]
+    getOrLoadFn GetOrLoadFunc[K, V]
+
+    // stopFns shuts down background work started by middleware Attach hooks
+    // (see Sweeper); called from Close.
+    stopFns []func()
+
+    // nsID is mixed into every key hash so that a namespaced sub-cache (see
+    // Namespace) never collides with the root cache or a sibling namespace,
+    // even when they share every shard. 0 is the root namespace.
+    nsID   uint32
+    nsName string
+
+    // nsHits/nsMisses/nsEvictions/nsBytes are counters scoped to this Cache
+    // instance alone, maintained at the Cache boundary rather than inside
+    // shard (shard's own hits/misses/evictions are namespace-agnostic
+    // aggregates across everything the shard holds). nsBytes is approximate:
+    // it is credited the full weight on every Put, including overwrites of
+    // an existing key, the same approximation shard.len() documents for its
+    // own counters.
+    nsHits      atomic.Uint64
+    nsMisses    atomic.Uint64
+    nsEvictions atomic.Uint64
+    nsBytes     atomic.Int64
+
+    // namespaces records every sub-cache carved out of the root via
+    // Namespace, keyed by name, so NamespaceStats can find them. Only
+    // populated on the root Cache returned by New.
+    nsMu       sync.Mutex
+    namespaces map[string]*Cache[K, V]
+
+    // registry and nsMetrics mirror each namespace's counters onto
+    // Prometheus with an "ns" label (see WithMetrics, namespaceMetrics). Both
+    // are nil when the Cache was built without WithMetrics.
+    registry  *prometheus.Registry
+    nsMetrics *namespaceMetrics
+
+    // metrics is the shard-level sink (counters + histograms) shared by
+    // every shard; see metrics.go. Always non-nil – a noopMetrics{} when the
+    // Cache was built without WithMetrics or WithOTelMeter.
+    metrics metricsSink
+
+    // exemplarExtractor pulls a tracing exemplar out of a call's context, if
+    // configured via WithExemplarExtractor. Nil means no exemplars.
+    exemplarExtractor func(context.Context) prometheus.Labels
+
+    // tracer wraps Get/Put in "arena_cache.get"/"arena_cache.put" spans when
+    // WithOTelTracer is configured; nil otherwise, in which case Get/Put skip
+    // span creation entirely.
+    tracer trace.Tracer
+
+    // cfg is the configuration New built this Cache from, kept around solely
+    // so Reconfigure can validate and re-derive values (capBytes/ttl/
+    // rotationStep) against it without the caller re-supplying every option.
+    // Only Reconfigure mutates it after construction, always while holding
+    // cfgMu.
+    cfgMu sync.Mutex
+    cfg   *config[K, V]
+}
+
+// exemplarLabels returns the exemplar for ctx via the configured
+// WithExemplarExtractor, or nil when none is configured or ctx carries no
+// span.
+func (c *Cache[K, V]) exemplarLabels(ctx context.Context) prometheus.Labels {
+    if c.exemplarExtractor == nil {
+        return nil
+    }
+    return c.exemplarExtractor(ctx)
 }
 
 // New creates a new cache instance with the specified capacity, TTL, and shard count.
@@ -288,27 +578,204 @@ func New[K comparable, V any](capBytes int64, ttl time.Duration, shards uint8, o
         return nil, err
     }
 
+    var tracer trace.Tracer
+    if cfg.otelTracerProvider != nil {
+        tracer = cfg.otelTracerProvider.Tracer("github.com/Voskan/arena-cache")
+    }
+
     // Initialize cache
     c := &Cache[K, V]{
-        shards: make([]*shard[K, V], shards),
+        shards:            make([]*shard[K, V], shards),
+        registry:          cfg.registry,
+        metrics:           newMetricsSink(int(shards), cfg.registry, cfg.histogramLatencyBuckets, cfg.histogramByteBuckets, cfg.otelMeter),
+        exemplarExtractor: cfg.exemplarExtractor,
+        tracer:            tracer,
+        cfg:               cfg,
+    }
+
+    // WithEjectCallbackAsync spliced ejectCb out in favour of asyncEjectCb;
+    // build the dispatcher and splice it back in now that cfg.registry is
+    // known to be final, then keep its drain goroutine running until Close.
+    ejectCb := cfg.ejectCb
+    if cfg.asyncEjectCb != nil {
+        dispatcher := newAsyncEjectDispatcher(cfg.asyncEjectCb, cfg.asyncEjectBufSize, cfg.asyncEjectOverflow, cfg.registry)
+        done := make(chan struct{})
+        go dispatcher.run(done)
+        c.stopFns = append(c.stopFns, func() { close(done) })
+        ejectCb = dispatcher.asEjectCallback
     }
+
     for i := range c.shards {
-        c.shards[i] = newShard(capBytes/int64(shards), ttl, cfg.weightFn, cfg.ejectCb)
+        c.shards[i] = newShard(uint8(i), capBytes/int64(shards), ttl, cfg.weightFn, ejectCb, cfg.fallback, cfg.fallbackWriteThrough, cfg.negativeTTL, cfg.isNegativeFn, cfg.negativeCapFraction, c.metrics, cfg.loaderCoalescing, cfg.loaderTimeout, cfg.hotFraction, cfg.testCapacity/int64(shards), tracer, cfg.generations)
+    }
+
+    // Wire the un-wrapped dispatch, then let each middleware wrap it in turn
+    // so the first one installed sees every call first.
+    c.getFn = func(ctx context.Context, key K) (V, bool) {
+        return c.shards[c.shardIndex(key)].get(c.nsID, key)
+    }
+    c.putFn = func(ctx context.Context, key K, value V, weight int) {
+        c.shards[c.shardIndex(key)].put(ctx, c.nsID, key, value, weight)
+    }
+    c.deleteFn = func(ctx context.Context, key K) {
+        c.shards[c.shardIndex(key)].delete(c.nsID, key)
+    }
+    c.getOrLoadFn = func(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
+        return c.shards[c.shardIndex(key)].getOrLoad(ctx, c.nsID, key, loader)
+    }
+
+    for i := len(cfg.middlewares) - 1; i >= 0; i-- {
+        mw := cfg.middlewares[i]
+        if mw.WrapGet != nil {
+            c.getFn = mw.WrapGet(c.getFn)
+        }
+        if mw.WrapPut != nil {
+            c.putFn = mw.WrapPut(c.putFn)
+        }
+        if mw.WrapDelete != nil {
+            c.deleteFn = mw.WrapDelete(c.deleteFn)
+        }
+        if mw.WrapGetOrLoad != nil {
+            c.getOrLoadFn = mw.WrapGetOrLoad(c.getOrLoadFn)
+        }
+    }
+    for _, mw := range cfg.middlewares {
+        if mw.Attach == nil {
+            continue
+        }
+        if stop := mw.Attach(c); stop != nil {
+            c.stopFns = append(c.stopFns, stop)
+        }
     }
 
     return c, nil
 }
 
-// Put inserts a value into the cache.
+// Reconfigure applies opts against a copy of the configuration New built
+// this Cache from and, if every option targets a field that is actually safe
+// to change at runtime (currently WithCapacity, WithTTL and WithHotFraction), pushes the
+// result out to every shard under its exclusive lock: genring's rotation
+// budget/window and CLOCK‑Pro's byte budget are both recomputed, and any
+// shard whose active generation already outlives the new TTL is rotated
+// immediately rather than waiting for the next Put or the Sweeper
+// middleware's next tick. Any option that would change the shard count,
+// WithWeightFn, or WithGenerations – baked in at construction, since
+// already-inserted entries would otherwise be weighed inconsistently with
+// new ones, and a live ring's generation count only ever changes through its
+// own autotuner (see genring.Ring.autotune) – is rejected with
+// ErrImmutableOption and the Cache is left exactly as it was.
+//
+// Reconfigure serialises against concurrent Reconfigure calls but not
+// against Get/Put: those only ever see either the old or the new
+// configuration for a given shard, never a half-updated one, since each
+// shard's own lock is held for the whole of its update.
+func (c *Cache[K, V]) Reconfigure(opts ...Option[K, V]) error {
+    c.cfgMu.Lock()
+    defer c.cfgMu.Unlock()
+
+    next := *c.cfg
+    prevShards := next.shards
+    prevWeightFn := reflect.ValueOf(next.weightFn).Pointer()
+    prevGenerations := next.generations
+
+    for _, opt := range opts {
+        opt(&next)
+    }
+
+    if next.shards != prevShards {
+        return fmt.Errorf("%w: shard count is fixed at construction", ErrImmutableOption)
+    }
+    if reflect.ValueOf(next.weightFn).Pointer() != prevWeightFn {
+        return fmt.Errorf("%w: WithWeightFn cannot be changed after construction", ErrImmutableOption)
+    }
+    if next.generations != prevGenerations {
+        return fmt.Errorf("%w: WithGenerations cannot be changed after construction", ErrImmutableOption)
+    }
+    if next.capBytes <= 0 {
+        return errInvalidCap
+    }
+    if next.ttl <= 0 {
+        return errInvalidTTL
+    }
+
+    generations := next.generations
+    if generations <= 0 {
+        const defaultGenerations = 4
+        generations = defaultGenerations
+    }
+    rotationStep := next.ttl / time.Duration(generations)
+    if rotationStep < time.Millisecond {
+        rotationStep = time.Millisecond
+    }
+    next.rotationStep = rotationStep
+
+    shardCapBytes := next.capBytes / int64(next.shards)
+    for _, sh := range c.shards {
+        sh.reconfigure(shardCapBytes, next.ttl, next.hotFraction)
+    }
+
+    c.cfg = &next
+    return nil
+}
+
+// Get looks up key without invoking a loader on miss. Latency is recorded via
+// WithMetrics as get_duration_seconds (see metricsSink.observeGetLatency);
+// the hit/miss counters and that observation carry a tracing exemplar when
+// WithExemplarExtractor is configured.
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, bool) {
+    if c.tracer != nil {
+        var span trace.Span
+        ctx, span = c.tracer.Start(ctx, "arena_cache.get")
+        defer span.End()
+    }
+
+    start := time.Now()
+    val, ok := c.getFn(ctx, key)
+
+    shardID := uint8(c.shardIndex(key))
+    exemplar := c.exemplarLabels(ctx)
+    if ok {
+        c.metrics.incHit(shardID, exemplar)
+    } else {
+        c.metrics.incMiss(shardID, exemplar)
+    }
+    c.metrics.observeGetLatency(shardID, time.Since(start), exemplar)
+    return val, ok
+}
+
+// Put inserts a value into the cache. weight is recorded via WithMetrics as
+// put_value_bytes (see metricsSink.observePutBytes).
 func (c *Cache[K, V]) Put(ctx context.Context, key K, value V, weight int) {
-    shard := c.shards[c.shardIndex(key)]
-    shard.put(key, value, weight)
+    if c.tracer != nil {
+        var span trace.Span
+        ctx, span = c.tracer.Start(ctx, "arena_cache.put")
+        defer span.End()
+    }
+
+    c.putFn(ctx, key, value, weight)
+    c.metrics.observePutBytes(uint8(c.shardIndex(key)), weight)
+}
+
+// Delete removes key from the cache, if present.
+func (c *Cache[K, V]) Delete(ctx context.Context, key K) {
+    c.deleteFn(ctx, key)
 }
 
-// GetOrLoad retrieves a value from the cache or loads it using the provided loader function.
+// GetOrLoad retrieves a value from the cache or loads it using the provided
+// loader function. The loader itself – not the overall call, which may be
+// satisfied entirely from cache – is timed and recorded via WithMetrics as
+// loader_duration_seconds (see metricsSink.observeLoaderLatency), carrying a
+// tracing exemplar when WithExemplarExtractor is configured.
 func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
-    shard := c.shards[c.shardIndex(key)]
-    return shard.getOrLoad(ctx, key, loader)
+    shardID := uint8(c.shardIndex(key))
+    exemplar := c.exemplarLabels(ctx)
+    timed := func(ctx context.Context, key K) (V, error) {
+        start := time.Now()
+        val, err := loader(ctx, key)
+        c.metrics.observeLoaderLatency(shardID, time.Since(start), exemplar)
+        return val, err
+    }
+    return c.getOrLoadFn(ctx, key, timed)
 }
 
 // Len returns the total number of items in the cache.
@@ -329,13 +796,232 @@ func (c *Cache[K, V]) SizeBytes() int64 {
     return total
 }
 
-// shardIndex calculates the index of the shard for a given key.
+// LoaderStats returns how many GetOrLoad misses reached the loader path
+// across every shard, and how many of those were served a shared in-flight
+// result instead of running the loader themselves (see
+// cache.WithLoaderCoalescing and loaderGroup). A high duplicates/loads ratio
+// indicates heavy coalescing – many concurrent misses on the same hot key.
+func (c *Cache[K, V]) LoaderStats() (loads, duplicates uint64) {
+    for _, shard := range c.shards {
+        l, d := shard.loadStats()
+        loads += l
+        duplicates += d
+    }
+    return loads, duplicates
+}
+
+// partitionHitRate reports the overall hit ratio across every shard, for
+// PartitionManager.Redistribute to weigh this Cache's share of the combined
+// budget against its siblings (see WithPartition). Returns 0 before any
+// Get/GetOrLoad has been served, same as a brand new partition with no
+// traffic yet.
+func (c *Cache[K, V]) partitionHitRate() float64 {
+    var hits, misses uint64
+    for _, sh := range c.shards {
+        h, m, _ := sh.statsSnapshot()
+        hits += h
+        misses += m
+    }
+    total := hits + misses
+    if total == 0 {
+        return 0
+    }
+    return float64(hits) / float64(total)
+}
+
+// partitionID reports the id this Cache was constructed with via
+// WithPartition (0 if that option was never applied), for Register to
+// validate against the id it was called with.
+func (c *Cache[K, V]) partitionID() int {
+    c.cfgMu.Lock()
+    defer c.cfgMu.Unlock()
+    return c.cfg.partID
+}
+
+// partitionCapacity reports the Cache's current capBytes, so a freshly
+// Registered partition starts out at its own existing share rather than
+// whatever PartitionManager.capBytes/n happens to compute before the first
+// Redistribute tick.
+func (c *Cache[K, V]) partitionCapacity() int64 {
+    c.cfgMu.Lock()
+    defer c.cfgMu.Unlock()
+    return c.cfg.capBytes
+}
+
+// partitionSetCapacity applies a PartitionManager-computed share through the
+// same Reconfigure path WithCapacity uses on its own, so a shrink forces the
+// usual immediate CLOCK-Pro eviction sweep (see shard.reconfigure) instead of
+// waiting for this partition to grow back into its old, now oversized,
+// budget on its own.
+func (c *Cache[K, V]) partitionSetCapacity(capBytes int64) error {
+    return c.Reconfigure(WithCapacity[K, V](capBytes))
+}
+
+// shardIndex calculates the index of the shard for a given key, within this
+// Cache's namespace. Shard *selection* always uses shard 0's seed (an
+// existing quirk predating namespaces); the selected shard then re-hashes
+// the key with its own seed – and, here, the namespace prefix – for the
+// actual index lookup.
 func (c *Cache[K, V]) shardIndex(key K) int {
-    return int(c.shards[0].hash(key) % uint64(len(c.shards)))
+    return int(c.shards[0].hashNS(c.nsID, key) % uint64(len(c.shards)))
+}
+
+// namespaceID derives a stable 32-bit id from a namespace name using the
+// shard hashing routine with a single process-wide seed – it only needs to be
+// stable across calls within a process, not cryptographically strong, and
+// must never collide with the reserved root id 0.
+func namespaceID(name string) uint32 {
+    var h maphash.Hash
+    h.SetSeed(namespaceSeed)
+    h.WriteString(name)
+    id := uint32(h.Sum64())
+    if id == 0 {
+        id = 1
+    }
+    return id
+}
+
+// namespaceSeed is shared by every namespaceID call in this process.
+var namespaceSeed = maphash.MakeSeed()
+
+// Namespace carves a logical sub-cache out of c, sharing its shards, arenas
+// and generation rotation: carving out a namespace does not allocate a
+// second cache's worth of memory, it just changes how keys are hashed. Keys
+// are transparently prefixed with an id derived from name before hashing, so
+// identical keys in different namespaces never collide in the shared shard
+// index. Calling Namespace twice with the same name on the same Cache
+// returns the same instance.
+//
+// Namespace is intended to be called on the root Cache returned by New;
+// namespaces do not themselves nest.
+func (c *Cache[K, V]) Namespace(name string) *Cache[K, V] {
+    c.nsMu.Lock()
+    defer c.nsMu.Unlock()
+
+    if c.namespaces == nil {
+        c.namespaces = make(map[string]*Cache[K, V])
+    }
+    if existing, ok := c.namespaces[name]; ok {
+        return existing
+    }
+
+    if c.registry != nil && c.nsMetrics == nil {
+        c.nsMetrics = newNamespaceMetrics(c.registry)
+    }
+    nsm := c.nsMetrics
+
+    ns := &Cache[K, V]{
+        shards:            c.shards,
+        nsID:              namespaceID(name),
+        nsName:            name,
+        metrics:           c.metrics,
+        exemplarExtractor: c.exemplarExtractor,
+    }
+    ns.getFn = func(ctx context.Context, key K) (V, bool) {
+        val, ok := ns.shards[ns.shardIndex(key)].get(ns.nsID, key)
+        if ok {
+            ns.nsHits.Add(1)
+            if nsm != nil {
+                nsm.hits.WithLabelValues(name).Inc()
+            }
+        } else {
+            ns.nsMisses.Add(1)
+            if nsm != nil {
+                nsm.misses.WithLabelValues(name).Inc()
+            }
+        }
+        return val, ok
+    }
+    ns.putFn = func(ctx context.Context, key K, value V, weight int) {
+        ns.shards[ns.shardIndex(key)].put(ctx, ns.nsID, key, value, weight)
+        total := ns.nsBytes.Add(int64(weight))
+        if nsm != nil {
+            nsm.bytes.WithLabelValues(name).Set(float64(total))
+        }
+    }
+    ns.deleteFn = func(ctx context.Context, key K) {
+        if weight, ok := ns.shards[ns.shardIndex(key)].delete(ns.nsID, key); ok {
+            ns.nsEvictions.Add(1)
+            total := ns.nsBytes.Add(-int64(weight))
+            if nsm != nil {
+                nsm.evictions.WithLabelValues(name).Inc()
+                nsm.bytes.WithLabelValues(name).Set(float64(total))
+            }
+        }
+    }
+    ns.getOrLoadFn = func(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
+        sh := ns.shards[ns.shardIndex(key)]
+
+        // shard.getOrLoad only calls loader on an actual cache miss (see its
+        // doc comment), so wrapping it tells hits from loads without needing
+        // a second, double-counting shard.get call of our own. A call
+        // answered by the L2 fallback (see WithFallback), or by another
+        // caller's in-flight load, never runs the wrapper either and is
+        // counted as a hit.
+        var loaderRan bool
+        wrapped := func(ctx context.Context, key K) (V, error) {
+            loaderRan = true
+            return loader(ctx, key)
+        }
+
+        val, err := sh.getOrLoad(ctx, ns.nsID, key, wrapped)
+        if !loaderRan && err == nil {
+            ns.nsHits.Add(1)
+            if nsm != nil {
+                nsm.hits.WithLabelValues(name).Inc()
+            }
+            return val, nil
+        }
+
+        ns.nsMisses.Add(1)
+        if nsm != nil {
+            nsm.misses.WithLabelValues(name).Inc()
+        }
+        if err == nil {
+            total := ns.nsBytes.Add(int64(sh.weightFn(val)))
+            if nsm != nil {
+                nsm.bytes.WithLabelValues(name).Set(float64(total))
+            }
+        }
+        return val, err
+    }
+
+    c.namespaces[name] = ns
+    return ns
+}
+
+// NamespaceStats returns the hits/misses/evictions/bytes counters for the
+// namespace previously created via Namespace(name), and false if no such
+// namespace exists on this Cache.
+func (c *Cache[K, V]) NamespaceStats(name string) (stats NamespaceStats, ok bool) {
+    c.nsMu.Lock()
+    ns, found := c.namespaces[name]
+    c.nsMu.Unlock()
+    if !found {
+        return NamespaceStats{}, false
+    }
+    return NamespaceStats{
+        Hits:      ns.nsHits.Load(),
+        Misses:    ns.nsMisses.Load(),
+        Evictions: ns.nsEvictions.Load(),
+        Bytes:     ns.nsBytes.Load(),
+    }, true
+}
+
+// NamespaceStats reports the counters tracked for a single namespace (see
+// Cache.Namespace / Cache.NamespaceStats).
+type NamespaceStats struct {
+    Hits      uint64
+    Misses    uint64
+    Evictions uint64
+    Bytes     int64
 }
 
 // Close releases resources used by the cache.
 func (c *Cache[K, V]) Close() {
+    for _, stop := range c.stopFns {
+        stop()
+    }
     for _, shard := range c.shards {
         shard.close()
     }
@@ -0,0 +1,63 @@
+package cache
+
+import (
+    "context"
+    "sync"
+    "testing"
+    "time"
+)
+
+// TestLoaderStats covers the chunk0-2 fix: shard.loads/loadDuplicates were
+// tracked but loadStats(), the accessor meant to expose them, was never
+// called from anywhere. LoaderStats aggregates it across shards so the
+// counters are actually reachable.
+func TestLoaderStats(t *testing.T) {
+    c, err := New[string, int](1<<20, time.Hour, 1)
+    if err != nil {
+        t.Fatalf("New: %v", err)
+    }
+    ctx := context.Background()
+
+    start := make(chan struct{})
+    release := make(chan struct{})
+    var ready sync.WaitGroup // every goroutine has reached GetOrLoad, about to call it
+    var loaderStarted sync.WaitGroup
+    loaderStarted.Add(1)
+    var once sync.Once
+
+    var wg sync.WaitGroup
+    const waiters = 4
+    ready.Add(waiters)
+    for i := 0; i < waiters; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            ready.Done()
+            <-start
+            _, _ = c.GetOrLoad(ctx, "k", func(ctx context.Context, key string) (int, error) {
+                once.Do(loaderStarted.Done)
+                <-release
+                return 1, nil
+            })
+        }()
+    }
+
+    ready.Wait()
+    close(start) // release every waiter at once so they race into the same singleflight call
+    loaderStarted.Wait()
+    // Give the other waiters time to join the in-flight singleflight call
+    // before we let the leader's loader return; otherwise a slow goroutine
+    // could still be scheduling its Do() call when release closes, landing
+    // as its own leader instead of a shared duplicate.
+    time.Sleep(20 * time.Millisecond)
+    close(release)
+    wg.Wait()
+
+    loads, duplicates := c.LoaderStats()
+    if loads == 0 {
+        t.Fatalf("loads = 0, want > 0 after concurrent GetOrLoad misses")
+    }
+    if duplicates == 0 {
+        t.Fatalf("duplicates = 0, want > 0 since %d goroutines raced the same key", waiters)
+    }
+}
@@ -0,0 +1,224 @@
+// Package cluster layers a groupcache-style distributed peer lookup in
+// front of a Cache[K,V]'s GetOrLoad: a key miss first consults whichever
+// node owns it on a consistent-hash ring (ring.go) before falling back to
+// the caller's LoaderFunc, so only the owning node ever actually runs the
+// loader for a given key. arena-cache stays usable single-process – a
+// Cluster built without peers behaves exactly like the Cache it wraps.
+//
+// © 2025 arena-cache authors. MIT License.
+package cluster
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    "golang.org/x/sync/singleflight"
+
+    cache "github.com/Voskan/arena-cache/pkg"
+)
+
+// errPeerMiss is returned internally when a peer reports key as absent; it
+// is never surfaced to callers, which instead see the local loader's
+// result for that case (see Cluster.GetOrLoad).
+var errPeerMiss = errors.New("cluster: peer miss")
+
+// KeyFunc renders a cache key as the bytes sent to Peer.Get and hashed onto
+// the ring.
+type KeyFunc[K comparable] func(K) []byte
+
+// ValueCodec converts values to/from the byte representation a Peer
+// transports over the wire.
+type ValueCodec[V any] interface {
+    Encode(V) ([]byte, error)
+    Decode([]byte) (V, error)
+}
+
+// Cluster wraps a Cache[K,V] with peer consultation on GetOrLoad. Use
+// GetOrLoad in place of the underlying Cache's; Get, Put and Delete can be
+// used directly on Cluster.Main() for calls that should stay purely local
+// (e.g. an owner node warming its own cache).
+//
+// Two underlying Cache instances back it, groupcache-style: main holds
+// values this node owns, hot holds values fetched from peers so a popular
+// remote key isn't re-fetched over the network on every local access. This
+// split is implemented at the Cache level rather than per-shard – Cluster
+// lives outside package cache and only sees its public API – which is an
+// intentional simplification of the groupcache design, not a different
+// guarantee: either way a hit in hot avoids both the ring lookup and the
+// peer round-trip.
+type Cluster[K comparable, V any] struct {
+    ring *Ring
+    self string
+    key  KeyFunc[K]
+    val  ValueCodec[V]
+
+    main *cache.Cache[K, V]
+    hot  *cache.Cache[K, V]
+
+    mu    sync.RWMutex
+    peers map[string]Peer
+
+    // fetch de-duplicates concurrent peer fetches for the same key, the
+    // same way loaderGroup de-duplicates concurrent local loads.
+    fetch singleflight.Group
+}
+
+// config bundles the knobs Option mutates before New builds the ring and
+// the two underlying caches.
+type config[K comparable, V any] struct {
+    replicas int
+}
+
+// Option configures a Cluster[K,V] at construction time.
+type Option[K comparable, V any] func(*config[K, V])
+
+// WithReplicas overrides the ring's virtual-node replica factor (default
+// defaultReplicas). Higher values spread a peer's share of the keyspace
+// more evenly at the cost of a larger ring.
+func WithReplicas[K comparable, V any](n int) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.replicas = n
+    }
+}
+
+func defaultConfig[K comparable, V any]() *config[K, V] {
+    return &config[K, V]{replicas: defaultReplicas}
+}
+
+// New builds a Cluster identified as self on the ring, with its own main
+// cache (capacity mainCapBytes) and hot cache (capacity hotCapBytes) both
+// built with the given ttl and shard count. A Cluster constructed with no
+// AddPeer calls is the NoPeers topology the request talks about: Owner
+// always reports ok=false, so GetOrLoad short-circuits straight to main,
+// identical to calling main.GetOrLoad directly.
+func New[K comparable, V any](
+    self string,
+    key KeyFunc[K],
+    val ValueCodec[V],
+    mainCapBytes, hotCapBytes int64,
+    ttl time.Duration,
+    shards uint8,
+    opts ...Option[K, V],
+) (*Cluster[K, V], error) {
+    cfg := defaultConfig[K, V]()
+    for _, opt := range opts {
+        opt(cfg)
+    }
+
+    main, err := cache.New[K, V](mainCapBytes, ttl, shards)
+    if err != nil {
+        return nil, err
+    }
+    hot, err := cache.New[K, V](hotCapBytes, ttl, shards)
+    if err != nil {
+        main.Close()
+        return nil, err
+    }
+
+    return &Cluster[K, V]{
+        ring:  NewRing(cfg.replicas),
+        self:  self,
+        key:   key,
+        val:   val,
+        main:  main,
+        hot:   hot,
+        peers: make(map[string]Peer),
+    }, nil
+}
+
+// Main returns the underlying Cache holding keys this node owns.
+func (c *Cluster[K, V]) Main() *cache.Cache[K, V] {
+    return c.main
+}
+
+// AddPeer registers p as reachable under id and advertises id on the ring.
+// Safe to call concurrently with GetOrLoad and with other AddPeer/RemovePeer
+// calls; existing in-flight GetOrLoad calls are never blocked by it.
+func (c *Cluster[K, V]) AddPeer(id string, p Peer) {
+    c.mu.Lock()
+    c.peers[id] = p
+    c.mu.Unlock()
+    c.ring.Add(id)
+}
+
+// RemovePeer takes id off the ring and drops its registered Peer. Safe to
+// call concurrently with GetOrLoad and with other AddPeer/RemovePeer calls.
+func (c *Cluster[K, V]) RemovePeer(id string) {
+    c.ring.Remove(id)
+    c.mu.Lock()
+    delete(c.peers, id)
+    c.mu.Unlock()
+}
+
+// GetOrLoad retrieves key from the hot cache, then the owning peer (if any
+// other than self), then – if this node owns key, or no peer could serve
+// it – from main, invoking loader on a local miss exactly as Cache.GetOrLoad
+// would. This is the key invariant the request calls for: an owner node's
+// behavior is unchanged, and loader only ever runs as a last resort on a
+// non-owner node.
+func (c *Cluster[K, V]) GetOrLoad(ctx context.Context, key K, loader cache.LoaderFunc[K, V]) (V, error) {
+    if val, ok := c.hot.Get(ctx, key); ok {
+        return val, nil
+    }
+
+    rawKey := c.key(key)
+    keyHash := c.ring.HashKey(rawKey)
+
+    owner, hasPeers := c.ring.Owner(keyHash)
+    if !hasPeers || owner == c.self {
+        return c.main.GetOrLoad(ctx, key, loader)
+    }
+
+    c.mu.RLock()
+    peer, ok := c.peers[owner]
+    c.mu.RUnlock()
+    if !ok {
+        // The ring advertises owner but we have no client for it – e.g. a
+        // RemovePeer raced with this lookup. Fall back to running the
+        // loader ourselves rather than failing the call.
+        return c.main.GetOrLoad(ctx, key, loader)
+    }
+
+    if val, weight, err := c.fetchFromPeer(ctx, keyHash, rawKey, peer); err == nil {
+        c.hot.Put(ctx, key, val, weight)
+        return val, nil
+    }
+    // Peer call failed (including a peer miss): only now does the caller's
+    // loader run, per the invariant above.
+    return c.main.GetOrLoad(ctx, key, loader)
+}
+
+// fetchFromPeer de-duplicates concurrent fetches of the same key across
+// goroutines, the same way loaderGroup de-duplicates concurrent local
+// loads, then decodes the winning call's result for every waiter.
+func (c *Cluster[K, V]) fetchFromPeer(ctx context.Context, keyHash uint64, rawKey []byte, peer Peer) (val V, weight int, err error) {
+    res, doErr, _ := c.fetch.Do(string(rawKey), func() (any, error) {
+        raw, ok, err := peer.Get(ctx, keyHash, rawKey)
+        if err != nil {
+            return nil, err
+        }
+        if !ok {
+            return nil, errPeerMiss
+        }
+        return raw, nil
+    })
+    if doErr != nil {
+        return val, 0, doErr
+    }
+    raw := res.([]byte)
+    val, err = c.val.Decode(raw)
+    if err != nil {
+        return val, 0, err
+    }
+    return val, len(raw), nil
+}
+
+// Close releases both underlying caches. It does not touch registered
+// peers – closing their transports, if needed, is the caller's
+// responsibility.
+func (c *Cluster[K, V]) Close() {
+    c.main.Close()
+    c.hot.Close()
+}
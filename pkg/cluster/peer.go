@@ -0,0 +1,21 @@
+package cluster
+
+// peer.go declares the transport abstraction a Cluster consults for keys it
+// does not own. arena-cache ships no HTTP/gRPC client itself – callers wire
+// whatever transport they already use for inter-node RPC.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import "context"
+
+// Peer is a remote node reachable over whatever transport the caller
+// chooses (HTTP, gRPC, …). Get fetches the serialized value for a key this
+// peer owns; the bool reports whether it was present, a miss is not an
+// error. keyHash is the same ring hash Cluster used to pick this peer,
+// passed through in case the transport wants to shard its own request
+// routing on it.
+//
+// Implementations must be safe for concurrent use.
+type Peer interface {
+    Get(ctx context.Context, keyHash uint64, key []byte) ([]byte, bool, error)
+}
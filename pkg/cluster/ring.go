@@ -0,0 +1,140 @@
+package cluster
+
+// ring.go implements a consistent-hash ring over peer IDs, with virtual
+// nodes so that adding or removing a single peer only reshuffles a small
+// fraction of the keyspace rather than every key.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "hash/fnv"
+    "sort"
+    "sync/atomic"
+)
+
+// defaultReplicas is the number of virtual nodes placed per peer when a
+// Ring is built without an explicit replica factor (see WithReplicas).
+const defaultReplicas = 160
+
+// Ring is a consistent-hash ring mapping a 64-bit key hash to the peer that
+// owns it. Add and Remove build a new immutable snapshot and swap it in
+// atomically, so Owner – called on every GetOrLoad miss – never blocks on,
+// or observes a half-updated view of, a concurrent topology change.
+//
+// The ring hashes peer IDs (and their virtual-node suffixes) with its own
+// FNV-1a, independent of the SipHash-64 each shard computes with a
+// per-process random seed: that seed is deliberately randomized to resist
+// hash-flooding within one process, so it cannot be shared across the
+// cluster. HashKey below gives callers a process-independent hash of the
+// same cache key, stable across every node in the ring.
+type Ring struct {
+    replicas int
+    snap     atomic.Pointer[ringSnapshot]
+}
+
+type ringSnapshot struct {
+    sorted []uint64
+    owner  map[uint64]string
+}
+
+var emptySnapshot = &ringSnapshot{}
+
+// NewRing constructs an empty ring with the given virtual-node replica
+// factor. A non-positive replicas selects defaultReplicas.
+func NewRing(replicas int) *Ring {
+    if replicas <= 0 {
+        replicas = defaultReplicas
+    }
+    r := &Ring{replicas: replicas}
+    r.snap.Store(emptySnapshot)
+    return r
+}
+
+// HashKey hashes a raw cache key into the same 64-bit space used to place
+// peers on the ring. See the Ring doc comment for why this is a dedicated
+// hash rather than the shard's own per-process SipHash.
+func (r *Ring) HashKey(key []byte) uint64 {
+    return fnv64(key)
+}
+
+func fnv64(b []byte) uint64 {
+    h := fnv.New64a()
+    h.Write(b)
+    return h.Sum64()
+}
+
+// Add places peerID on the ring at r.replicas virtual-node positions. Safe
+// to call concurrently with Owner and with other Add/Remove calls.
+func (r *Ring) Add(peerID string) {
+    r.update(func(s *ringSnapshot) *ringSnapshot {
+        next := cloneSnapshot(s)
+        for i := 0; i < r.replicas; i++ {
+            next.owner[virtualNodeHash(peerID, i)] = peerID
+        }
+        next.sorted = sortedHashes(next.owner)
+        return next
+    })
+}
+
+// Remove takes peerID off the ring, including all of its virtual nodes.
+// Safe to call concurrently with Owner and with other Add/Remove calls.
+func (r *Ring) Remove(peerID string) {
+    r.update(func(s *ringSnapshot) *ringSnapshot {
+        next := cloneSnapshot(s)
+        for i := 0; i < r.replicas; i++ {
+            delete(next.owner, virtualNodeHash(peerID, i))
+        }
+        next.sorted = sortedHashes(next.owner)
+        return next
+    })
+}
+
+// Owner returns the peer ID responsible for keyHash, walking clockwise to
+// the nearest virtual node. ok is false when the ring has no peers – the
+// NoPeers topology single-node callers get by default – in which case the
+// caller should treat every key as locally owned.
+func (r *Ring) Owner(keyHash uint64) (peerID string, ok bool) {
+    s := r.snap.Load()
+    if len(s.sorted) == 0 {
+        return "", false
+    }
+    idx := sort.Search(len(s.sorted), func(i int) bool { return s.sorted[i] >= keyHash })
+    if idx == len(s.sorted) {
+        idx = 0
+    }
+    return s.owner[s.sorted[idx]], true
+}
+
+func (r *Ring) update(fn func(*ringSnapshot) *ringSnapshot) {
+    for {
+        old := r.snap.Load()
+        next := fn(old)
+        if r.snap.CompareAndSwap(old, next) {
+            return
+        }
+    }
+}
+
+func cloneSnapshot(s *ringSnapshot) *ringSnapshot {
+    owner := make(map[uint64]string, len(s.owner))
+    for h, id := range s.owner {
+        owner[h] = id
+    }
+    return &ringSnapshot{owner: owner}
+}
+
+func sortedHashes(owner map[uint64]string) []uint64 {
+    hashes := make([]uint64, 0, len(owner))
+    for h := range owner {
+        hashes = append(hashes, h)
+    }
+    sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+    return hashes
+}
+
+func virtualNodeHash(peerID string, replica int) uint64 {
+    h := fnv.New64a()
+    h.Write([]byte(peerID))
+    h.Write([]byte{byte(replica), byte(replica >> 8)})
+    return h.Sum64()
+}
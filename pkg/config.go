@@ -19,15 +19,20 @@ package cache
 // © 2025 arena-cache authors. MIT License.
 
 import (
+	"context"
 	"time"
 	"unsafe"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"errors"
 
 	"github.com/Voskan/arena-cache/internal/clockpro"
+	"github.com/Voskan/arena-cache/pkg/provider"
 )
 
 // WeightFn calculates an integer weight for the stored value V. The number is
@@ -59,9 +64,13 @@ type EjectCallback[K comparable, V any] func(key K, val V, reason EjectReason)
 
 type Option[K comparable, V any] func(*config[K, V])
 
-// config bundles every knob that influences cache behaviour.  All fields are
-// immutable once the Cache is constructed – we do not support live mutation
-// from user land; hot‑reload of TTL etc. would complicate correctness proofs.
+// config bundles every knob that influences cache behaviour.  Most fields are
+// immutable once the Cache is constructed – wiring middleware or swapping the
+// weight function mid‑flight would leave already‑inserted entries in an
+// inconsistent state.  capBytes, ttl and hotFraction are the exception:
+// Cache.Reconfigure re‑applies WithCapacity/WithTTL/WithHotFraction against a
+// copy of this struct at runtime
+// (see Reconfigure and ErrImmutableOption).
 
 type config[K comparable, V any] struct {
     // memory & shards are copied from the New() arguments; kept here just for
@@ -75,7 +84,91 @@ type config[K comparable, V any] struct {
     logger    *zap.Logger
     weightFn  WeightFn[V]
     ejectCb   EjectCallback[K, V]
-    partID    int // reserved for future partition‑pinning feature
+    partID    int // partition id this Cache was pinned to via WithPartition; 0 if never set
+
+    // asyncEjectCb, when set via WithEjectCallbackAsync, tells New to build
+    // an asyncEjectDispatcher and splice it in place of ejectCb instead of
+    // calling the user's callback directly (see eject_async.go).
+    // asyncEjectBufSize and asyncEjectOverflow carry that option's other two
+    // arguments alongside it.
+    asyncEjectCb       EjectCallback[K, V]
+    asyncEjectBufSize  int
+    asyncEjectOverflow OverflowPolicy
+
+    // fallback is an optional L2 provider consulted on GetOrLoad miss (before
+    // the user loader) and, when fallbackWriteThrough is set, written to on
+    // every Put.
+    fallback            provider.Provider[K, V]
+    fallbackWriteThrough bool
+
+    // negativeTTL, when > 0, enables tombstone caching of loader errors
+    // classified by isNegativeFn (see WithNegativeTTL and ErrNotFound).
+    negativeTTL time.Duration
+
+    // isNegativeFn classifies which loader errors GetOrLoad caches as
+    // tombstones (see WithIsNegative). Defaults to the package's isNegative
+    // (ErrNotFound/ErrGone). Has no effect without WithNegativeTTL.
+    isNegativeFn func(error) bool
+
+    // negativeCapFraction bounds the fraction of a shard's capacity bytes
+    // that tombstones may occupy before the soonest-to-expire are evicted
+    // to make room for new ones (see WithNegativeCapacityFraction).
+    negativeCapFraction float64
+
+    // hotFraction caps the CLOCK‑Pro adaptive HOT target as a fraction of a
+    // shard's capacity bytes (see WithHotFraction). Non-positive or >1
+    // leaves clockpro's own default (0.5) in place.
+    hotFraction float64
+
+    // testCapacity bounds how much weight CLOCK‑Pro's ghost (TEST) list may
+    // remember across the whole cache, independently of live capacity (see
+    // WithTestCapacity); split evenly across shards. Non-positive leaves
+    // clockpro's own default (one capacity's worth of weight, per shard) in
+    // place.
+    testCapacity int64
+
+    // generations pins each shard's genring.Ring to an explicit generation
+    // count (see WithGenerations), clamped to [2, 16]. Non-positive (the
+    // default) leaves genring's autotuner in charge instead, which derives
+    // the count from capBytes and a rolling EWMA of observed generation
+    // sizes.
+    generations int
+
+    // loaderCoalescing enables per-key singleflight deduplication of
+    // concurrent GetOrLoad misses (see WithLoaderCoalescing and
+    // loaderGroup). On by default.
+    loaderCoalescing bool
+
+    // loaderTimeout, when > 0, bounds how long a single LoaderFunc
+    // invocation may run before loaderGroup hands leadership to the next
+    // waiter instead of letting every waiter fail with it (see
+    // WithLoaderTimeout).
+    loaderTimeout time.Duration
+
+    // middlewares wrap Get/Put/Delete/GetOrLoad at the Cache boundary, in the
+    // order installed via Use (see middleware.go).
+    middlewares []Middleware[K, V]
+
+    // histogramLatencyBuckets/histogramByteBuckets override the
+    // explicit-bucket fallback used by the latency and value-size
+    // histograms recorded via WithMetrics, in case the registry rejects
+    // native histograms (see WithHistogramBuckets). Nil selects the
+    // package defaults.
+    histogramLatencyBuckets []float64
+    histogramByteBuckets    []float64
+
+    // exemplarExtractor, when set, is consulted on every Get/GetOrLoad call
+    // to pull a tracing exemplar (e.g. {trace_id, span_id}) out of the
+    // caller's context.Context, attached to Get's hit/miss counter and
+    // latency observation and to GetOrLoad's loader-latency observation (see
+    // WithExemplarExtractor).
+    exemplarExtractor func(context.Context) prometheus.Labels
+
+    // otelMeter/otelTracerProvider mirror registry's role for operators on
+    // the OpenTelemetry stack instead of (or alongside) Prometheus – see
+    // WithOTelMeter and WithOTelTracer. Either, both, or neither may be set.
+    otelMeter          metric.Meter
+    otelTracerProvider trace.TracerProvider
 
     // derived / pre‑computed values – filled in finalise().
     rotationStep time.Duration
@@ -98,9 +191,13 @@ func defaultConfig[K comparable, V any](capBytes int64, ttl time.Duration, shard
         capBytes: capBytes,
         ttl:      ttl,
         shards:   shards,
-        weightFn: defaultWeightFn[V],
-        logger:   zap.NewNop(),
-        registry: nil, // user must opt‑in to metrics
+        weightFn:         defaultWeightFn[V],
+        logger:           zap.NewNop(),
+        registry:         nil, // user must opt‑in to metrics
+        loaderCoalescing: true,
+        isNegativeFn:         isNegative,
+        negativeCapFraction: 0.05,
+        hotFraction:         0.5,
     }
 }
 
@@ -108,6 +205,40 @@ func defaultConfig[K comparable, V any](capBytes int64, ttl time.Duration, shard
    ---------------- Functional options exposed to users ----------------
 */
 
+// WithCapacity overrides the capacity passed to New. Outside of New's option
+// list it is only meaningful via Cache.Reconfigure, which re-derives every
+// shard's byte budget (capBytes/shards) and CLOCK-Pro's adaptive HOT ceiling
+// from the new value. Values <= 0 are rejected by whichever of New or
+// Reconfigure applies the option.
+func WithCapacity[K comparable, V any](capBytes int64) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.capBytes = capBytes
+    }
+}
+
+// WithTTL overrides the ttl passed to New, and therefore the derived
+// rotationStep (see applyOptions). Outside of New's option list it is only
+// meaningful via Cache.Reconfigure, which re-derives every shard's genring
+// rotation window from the new value and rotates immediately if a live
+// generation already exceeds it. Values <= 0 are rejected by whichever of New
+// or Reconfigure applies the option.
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.ttl = ttl
+    }
+}
+
+// WithGenerations pins each shard's genring.Ring to an explicit generation
+// count instead of letting it autotune (the default): n is clamped to
+// [2, 16] – below 2 a single generation would dominate the whole TTL window,
+// above 16 per-generation byte budgets (capBytes/n) get too small to be
+// useful. Non-positive n leaves the autotuner enabled.
+func WithGenerations[K comparable, V any](n int) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.generations = n
+    }
+}
+
 // WithMetrics enables Prometheus metrics collection for the cache instance.
 // Passing nil disables metrics (default).
 func WithMetrics[K comparable, V any](reg *prometheus.Registry) Option[K, V] {
@@ -143,11 +274,191 @@ func WithWeightFn[K comparable, V any](fn WeightFn[V]) Option[K, V] {
 func WithEjectCallback[K comparable, V any](cb EjectCallback[K, V]) Option[K, V] {
     return func(c *config[K, V]) {
         c.ejectCb = cb
+        c.asyncEjectCb = nil
+    }
+}
+
+// WithEjectCallbackAsync is WithEjectCallback without the "must not block"
+// requirement: cb runs on a single dedicated goroutine that drains a
+// bufSize-deep buffer, so the evicting goroutine never waits on cb, only on
+// handing the event off to the buffer. onOverflow decides what happens once
+// cb falls behind and the buffer fills up (see OverflowPolicy); overflow
+// events are counted per-policy on the "arena_cache_eject_async_overflow_total"
+// counter when WithMetrics is also configured. Mutually exclusive with
+// WithEjectCallback – whichever option runs last wins.
+func WithEjectCallbackAsync[K comparable, V any](cb EjectCallback[K, V], bufSize int, onOverflow OverflowPolicy) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.asyncEjectCb = cb
+        c.asyncEjectBufSize = bufSize
+        c.asyncEjectOverflow = onOverflow
+        c.ejectCb = nil
+    }
+}
+
+// WithFallback layers p as an L2 provider behind the cache: on GetOrLoad miss
+// the shard consults p before invoking the caller's LoaderFunc, and – when
+// combined with WithFallbackWriteThrough – every Put is mirrored to p. This
+// turns arena-cache into the fast L1 in front of a slower, shared, or durable
+// store (Redis, Memcached, Pebble, …) instead of a single-process-only cache.
+func WithFallback[K comparable, V any](p provider.Provider[K, V]) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.fallback = p
+    }
+}
+
+// WithFallbackWriteThrough enables synchronous write-through to the L2
+// provider configured via WithFallback. It has no effect without a fallback.
+// Disabled by default: most L2 stores are populated lazily, from the
+// fallback reads performed on GetOrLoad miss, or by the caller itself.
+func WithFallbackWriteThrough[K comparable, V any](enabled bool) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.fallbackWriteThrough = enabled
+    }
+}
+
+// WithNegativeTTL enables tombstone caching of negative loader results: when
+// a LoaderFunc returns an error satisfying isNegative (ErrNotFound or
+// ErrGone by default), GetOrLoad remembers that outcome for d and replays it
+// on subsequent calls for the same key instead of invoking the loader again.
+// Tombstones cost no arena allocation – only a small metadata entry – and
+// are treated as absent once d elapses. They leave the index when the
+// Sweeper middleware removes expired ones, when a Put or Delete for the same
+// key replaces them, or when WithNegativeCapacityFraction's budget evicts the
+// soonest-to-expire ones; generation rotation does not remove them. Disabled
+// (d <= 0) by default.
+func WithNegativeTTL[K comparable, V any](d time.Duration) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.negativeTTL = d
+    }
+}
+
+// WithIsNegative overrides which loader errors GetOrLoad caches as
+// tombstones (see WithNegativeTTL); it defaults to ErrNotFound/ErrGone.
+// Passing nil leaves the default in place. Has no effect without
+// WithNegativeTTL.
+func WithIsNegative[K comparable, V any](fn func(error) bool) Option[K, V] {
+    return func(c *config[K, V]) {
+        if fn != nil {
+            c.isNegativeFn = fn
+        }
+    }
+}
+
+// WithNegativeCapacityFraction bounds the fraction of a shard's capacity
+// bytes (see New) that tombstones may occupy. Once that budget is
+// exceeded, putTombstone evicts the soonest-to-expire tombstones to make
+// room for the new one – independently of, and much cheaper than, CLOCK‑Pro's
+// hot/cold/test admission, since tombstones never pin arena memory. Default
+// 0.05 (5%). Non-positive values leave the default in place. Has no effect
+// without WithNegativeTTL.
+func WithNegativeCapacityFraction[K comparable, V any](f float64) Option[K, V] {
+    return func(c *config[K, V]) {
+        if f > 0 {
+            c.negativeCapFraction = f
+        }
     }
 }
 
-// Reserved for future public API – partition pinning.
-// func WithPartition[K comparable, V any](id int) Option[K, V] { … }
+// WithHotFraction caps the CLOCK‑Pro adaptive HOT target as a fraction of
+// capacity (see the Clock-Pro paper's Min/Max HOT bounds): the target starts
+// at 0 and grows towards this ceiling as ghost entries are hit again,
+// demoting the oldest un-referenced HOT entry to COLD whenever the live HOT
+// set outgrows it – which is what makes a long sequential scan unable to
+// evict genuinely hot keys. Default 0.5 (HOT may claim up to half of
+// capacity). Values outside (0, 1] leave the default in place.
+func WithHotFraction[K comparable, V any](f float64) Option[K, V] {
+    return func(c *config[K, V]) {
+        if f > 0 && f <= 1 {
+            c.hotFraction = f
+        }
+    }
+}
+
+// WithTestCapacity bounds how much weight CLOCK‑Pro's ghost (TEST) list may
+// remember across the whole cache, independently of live capacity bytes –
+// ghost metadata costs no arena allocation, but an unbounded TEST list would
+// remember every key ever evicted, defeating the point of admission history
+// being a *recent* signal. The budget is split evenly across shards.
+// Non-positive values leave the default (one capacity's worth of weight, per
+// shard) in place.
+func WithTestCapacity[K comparable, V any](n int64) Option[K, V] {
+    return func(c *config[K, V]) {
+        if n > 0 {
+            c.testCapacity = n
+        }
+    }
+}
+
+// WithLoaderCoalescing toggles per-key singleflight deduplication of
+// concurrent GetOrLoad misses (see loaderGroup): with it enabled (the
+// default), the first goroutine to miss on a key runs the loader while
+// concurrent callers for the same key wait on its shared result instead of
+// invoking the loader themselves. Disable it only if your LoaderFunc is
+// already cheap and safe to call concurrently and you'd rather avoid the
+// bookkeeping.
+func WithLoaderCoalescing[K comparable, V any](enabled bool) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.loaderCoalescing = enabled
+    }
+}
+
+// WithLoaderTimeout bounds how long a single LoaderFunc invocation may run
+// before loaderGroup gives up on the goroutine that started it and hands
+// leadership to the next waiter, rather than letting a slow or stuck leader
+// fail every concurrent caller on that key. Zero (the default) disables the
+// timeout – the loader runs for as long as the caller's own context allows.
+// With WithLoaderCoalescing(false) the timeout still bounds every loader
+// call's context; there is just no waiter to hand leadership to.
+func WithLoaderTimeout[K comparable, V any](d time.Duration) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.loaderTimeout = d
+    }
+}
+
+// WithHistogramBuckets overrides the explicit-bucket boundaries used as a
+// fallback for the latency and put-value-size histograms recorded via
+// WithMetrics, for when a registry rejects Prometheus native histograms.
+// Passing nil for either slice leaves that series at its package default
+// (prometheus.DefBuckets for latency, an exponential 64B-to-16MiB ladder for
+// byte sizes). Has no effect without WithMetrics.
+func WithHistogramBuckets[K comparable, V any](latency, byteSizes []float64) Option[K, V] {
+    return func(c *config[K, V]) {
+        if latency != nil {
+            c.histogramLatencyBuckets = latency
+        }
+        if byteSizes != nil {
+            c.histogramByteBuckets = byteSizes
+        }
+    }
+}
+
+// WithExemplarExtractor plugs a tracing library into WithMetrics without
+// arena-cache taking a hard dependency on any of them: fn is called with the
+// ctx passed to Get/GetOrLoad and should return the exemplar labels for the
+// currently active span (commonly {"trace_id": ..., "span_id": ...}), or nil
+// if ctx carries no span. The result is attached, via Prometheus's
+// ExemplarAdder/ExemplarObserver, to the hit/miss counter and latency
+// observation a Get records and to the loader-latency observation a
+// GetOrLoad records (GetOrLoad records no hit/miss counter of its own), so a
+// scrape-time-linked Grafana panel can
+// jump straight from a miss spike to the offending trace. Has no effect
+// without WithMetrics.
+func WithExemplarExtractor[K comparable, V any](fn func(context.Context) prometheus.Labels) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.exemplarExtractor = fn
+    }
+}
+
+// WithPartition pins a Cache instance to logical partition id so that
+// PartitionManager.Register can later fold it into a global memory budget
+// shared with every other partition Registered on the same manager (see
+// partition.go). On its own – without ever being Registered – it has no
+// effect on the Cache's behaviour, it only labels it.
+func WithPartition[K comparable, V any](id int) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.partID = id
+    }
+}
 
 /*
    ---------------- Helper: apply options & validate ----------------
@@ -170,12 +481,22 @@ func applyOptions[K comparable, V any](cfg *config[K, V], opts []Option[K, V]) e
     if cfg.shards == 0 || (cfg.shards&(cfg.shards-1)) != 0 {
         return errInvalidShards
     }
+    if cfg.asyncEjectCb != nil && cfg.asyncEjectBufSize <= 0 {
+        return errInvalidAsyncEjectBuf
+    }
 
     // Derive rotation step: we want at least two generations to coexist, so we
-    // split TTL into (#gens) slots where #gens = ceil(capBytes / avgArenaSize).
-    // For now we assume 4 generations; in future we might autotune this.
-    const generations = 4
-    cfg.rotationStep = cfg.ttl / generations
+    // split TTL into (#gens) slots. If WithGenerations pinned an explicit
+    // count we use it directly; otherwise genring's autotuner is in charge
+    // of the real count per shard (see genring.Ring.autotune), so we assume
+    // its starting point here – this value is only a coarse initial guess
+    // for anything that reads rotationStep before the autotuner converges.
+    generations := cfg.generations
+    if generations <= 0 {
+        const defaultGenerations = 4
+        generations = defaultGenerations
+    }
+    cfg.rotationStep = cfg.ttl / time.Duration(generations)
     if cfg.rotationStep < time.Millisecond {
         cfg.rotationStep = time.Millisecond
     }
@@ -187,7 +508,25 @@ func applyOptions[K comparable, V any](cfg *config[K, V], opts []Option[K, V]) e
 */
 
 var (
-    errInvalidCap    = errors.New("capacity bytes must be > 0")
-    errInvalidTTL    = errors.New("ttl must be > 0")
-    errInvalidShards = errors.New("shards must be power‑of‑two and > 0")
+    errInvalidCap           = errors.New("capacity bytes must be > 0")
+    errInvalidTTL           = errors.New("ttl must be > 0")
+    errInvalidShards        = errors.New("shards must be power‑of‑two and > 0")
+    errInvalidAsyncEjectBuf = errors.New("cache: WithEjectCallbackAsync bufSize must be > 0")
 )
+
+// ErrImmutableOption is returned by Cache.Reconfigure when one of the
+// supplied options targets a field that cannot change after construction –
+// currently WithWeightFn (already-inserted entries would be weighed
+// inconsistently with new ones), WithGenerations (a live ring's generation
+// count is fixed at New) and any option that would alter the shard count
+// (key→shard routing is fixed at New). Wrap it with fmt.Errorf's %w
+// to name the offending option; callers can still test with errors.Is.
+var ErrImmutableOption = errors.New("cache: option cannot be applied via Reconfigure")
+
+// ErrPartitionMismatch is returned by Register when the id argument does not
+// match the id the Cache was constructed with via WithPartition.
+var ErrPartitionMismatch = errors.New("cache: partition id does not match WithPartition")
+
+// ErrPartitionExists is returned by Register when id is already Registered
+// with the target PartitionManager.
+var ErrPartitionExists = errors.New("cache: partition already registered")
@@ -0,0 +1,154 @@
+package cache
+
+// eject_async.go adds an optional asynchronous path for EjectCallback: the
+// synchronous contract installed by WithEjectCallback runs in whichever
+// goroutine triggered the eviction and "must not block", per that option's
+// docs. WithEjectCallbackAsync instead pushes each eviction onto a bounded
+// ring buffer and calls the user's callback from a single dedicated
+// goroutine, so the hot path never waits on – or allocates for, beyond the
+// fixed buffer – arbitrarily slow IO in a callback.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "sync"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// OverflowPolicy controls what happens when WithEjectCallbackAsync's buffer
+// is full and a new eviction arrives before the dedicated goroutine has
+// drained the backlog.
+type OverflowPolicy int
+
+const (
+    // DropOldest discards the oldest buffered event to make room for the new
+    // one, favouring recency over completeness.
+    DropOldest OverflowPolicy = iota
+    // DropNewest discards the incoming event, keeping the buffer exactly as
+    // it was. Favours not losing history over surfacing the latest eviction.
+    DropNewest
+    // BlockCaller blocks the evicting goroutine until the drain goroutine
+    // frees a slot – the same backpressure a synchronous EjectCallback
+    // already implies, just deferred until the buffer is actually full
+    // rather than applied on every single eviction.
+    BlockCaller
+)
+
+// String renders the policy the way it appears in the overflow counter's
+// "policy" label.
+func (p OverflowPolicy) String() string {
+    switch p {
+    case DropOldest:
+        return "drop_oldest"
+    case DropNewest:
+        return "drop_newest"
+    case BlockCaller:
+        return "block_caller"
+    default:
+        return "unknown"
+    }
+}
+
+// ejectEvent is one buffered eviction, queued by the goroutine CLOCK‑Pro
+// evicted it on and dequeued by asyncEjectDispatcher.run.
+type ejectEvent[K comparable, V any] struct {
+    key    K
+    val    V
+    reason EjectReason
+}
+
+// asyncEjectDispatcher owns the bounded buffer and drain goroutine backing
+// WithEjectCallbackAsync. One is built per Cache that configures the option;
+// push is called from the eviction path (in place of calling cb directly),
+// run drains buf on its own goroutine until stopped.
+type asyncEjectDispatcher[K comparable, V any] struct {
+    buf      chan ejectEvent[K, V]
+    policy   OverflowPolicy
+    cb       EjectCallback[K, V]
+    overflow prometheus.Counter // nil when no registry was configured
+
+    popMu sync.Mutex // serialises DropOldest's pop‑then‑push against itself
+}
+
+// newAsyncEjectDispatcher builds a dispatcher with a buf-sized channel.
+// reg may be nil, in which case overflow events simply aren't counted.
+func newAsyncEjectDispatcher[K comparable, V any](cb EjectCallback[K, V], bufSize int, policy OverflowPolicy, reg *prometheus.Registry) *asyncEjectDispatcher[K, V] {
+    d := &asyncEjectDispatcher[K, V]{
+        buf:    make(chan ejectEvent[K, V], bufSize),
+        policy: policy,
+        cb:     cb,
+    }
+    if reg != nil {
+        counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Name:      "eject_async_overflow_total",
+            Help:      "Evictions that found WithEjectCallbackAsync's buffer full, by the OverflowPolicy that handled them.",
+        }, []string{"policy"})
+        reg.MustRegister(counter)
+        d.overflow = counter.WithLabelValues(policy.String())
+    }
+    return d
+}
+
+// asEjectCallback adapts d.push to the EjectCallback[K,V] shape, so it can
+// be spliced into cfg.ejectCb in place of the user's callback – see New.
+func (d *asyncEjectDispatcher[K, V]) asEjectCallback(key K, val V, reason EjectReason) {
+    d.push(ejectEvent[K, V]{key: key, val: val, reason: reason})
+}
+
+// push enqueues ev according to d.policy, never blocking the caller except
+// under BlockCaller once the buffer is genuinely full.
+func (d *asyncEjectDispatcher[K, V]) push(ev ejectEvent[K, V]) {
+    switch d.policy {
+    case DropNewest:
+        select {
+        case d.buf <- ev:
+        default:
+            d.incOverflow()
+        }
+
+    case DropOldest:
+        d.popMu.Lock()
+        defer d.popMu.Unlock()
+        select {
+        case d.buf <- ev:
+            return
+        default:
+        }
+        d.incOverflow()
+        select {
+        case <-d.buf: // make room by discarding the oldest buffered event
+        default:
+        }
+        d.buf <- ev
+
+    default: // BlockCaller
+        select {
+        case d.buf <- ev:
+        default:
+            d.incOverflow()
+            d.buf <- ev
+        }
+    }
+}
+
+func (d *asyncEjectDispatcher[K, V]) incOverflow() {
+    if d.overflow != nil {
+        d.overflow.Inc()
+    }
+}
+
+// run drains buf on the calling goroutine until done is closed, invoking cb
+// for every buffered event. Intended to be started with `go`. Events still
+// sitting in buf when done closes are dropped rather than flushed.
+func (d *asyncEjectDispatcher[K, V]) run(done <-chan struct{}) {
+    for {
+        select {
+        case ev := <-d.buf:
+            d.cb(ev.key, ev.val, ev.reason)
+        case <-done:
+            return
+        }
+    }
+}
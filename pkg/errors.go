@@ -0,0 +1,26 @@
+package cache
+
+// errors.go declares the sentinel errors a LoaderFunc can return to signal a
+// negative result (the upstream system positively knows the key does not
+// exist, e.g. an HTTP 404/410 or a DB row-missing lookup) rather than a
+// transient failure. When WithNegativeTTL is configured, GetOrLoad caches
+// such errors as a lightweight tombstone so repeated requests for the same
+// absent key don't keep re-invoking an expensive loader.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import "errors"
+
+// ErrNotFound should be returned by a LoaderFunc when the upstream system
+// reports the key does not exist (e.g. HTTP 404).
+var ErrNotFound = errors.New("cache: not found")
+
+// ErrGone should be returned by a LoaderFunc when the upstream system reports
+// the key used to exist but has been permanently removed (e.g. HTTP 410).
+var ErrGone = errors.New("cache: gone")
+
+// isNegative reports whether err should be cached as a tombstone rather than
+// simply propagated to the caller.
+func isNegative(err error) bool {
+    return errors.Is(err, ErrNotFound) || errors.Is(err, ErrGone)
+}
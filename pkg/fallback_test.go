@@ -0,0 +1,49 @@
+package cache
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// fakeProvider is a minimal in-memory provider.Provider[string,int] used to
+// observe the ttl argument Cache.Put passes through WithFallbackWriteThrough.
+type fakeProvider struct {
+    lastTTL int64
+}
+
+func (p *fakeProvider) Get(ctx context.Context, key string) (int, bool, error) {
+    return 0, false, nil
+}
+
+func (p *fakeProvider) Set(ctx context.Context, key string, val int, weight int, ttl int64) error {
+    p.lastTTL = ttl
+    return nil
+}
+
+func (p *fakeProvider) Delete(ctx context.Context, key string) error { return nil }
+
+func (p *fakeProvider) Close() error { return nil }
+
+// TestPutWriteThroughUsesShardTTL covers the chunk0-1 fix: shard.put used to
+// hardcode ttl=0 ("no expiry") on the L2 write-through Set call, so a value
+// written through to the fallback never expired there even after its
+// in-process generation rotated it out of L1 – silently defeating the
+// cache's TTL contract whenever WithFallback+write-through is enabled.
+func TestPutWriteThroughUsesShardTTL(t *testing.T) {
+    p := &fakeProvider{}
+    ttl := 5 * time.Minute
+    c, err := New[string, int](1<<20, ttl, 1,
+        WithFallback[string, int](p),
+        WithFallbackWriteThrough[string, int](true),
+    )
+    if err != nil {
+        t.Fatalf("New: %v", err)
+    }
+
+    c.Put(context.Background(), "k", 1, 8)
+
+    if p.lastTTL != int64(ttl) {
+        t.Fatalf("fallback.Set ttl = %d, want %d (the cache's configured TTL)", p.lastTTL, int64(ttl))
+    }
+}
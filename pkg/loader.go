@@ -9,38 +9,36 @@ package cache
 //   • keys remain strongly typed (K comparable) yet singleflight still needs a
 //     string key → we use the 64‑bit hash already computed by the shard.
 //   • the public LoaderFunc[K,V] signature stays convenient.
-//   • we expose both *sync* and *async* APIs while keeping allocations lowest
-//     possible (LoadResult is passed by value; channels are re‑used via sync.Pool
-//     in the async path).
 //
 // © 2025 arena-cache authors. MIT License.
 
 import (
-	"context"
-	"strconv"
+    "context"
+    "errors"
+    "strconv"
+    "time"
 
-	"golang.org/x/sync/singleflight"
+    "golang.org/x/sync/singleflight"
 )
 
 /*
-   ---------------- Public types ----------------
+   ---------------- loaderGroup ----------------
 */
 
-// LoaderFunc is declared in shard.go (public).  Re‑using it here.
-
-// LoadResult holds the outcome of an asynchronous load.
-// Shared == true means this goroutine did not execute the loader itself – it
-// received a shared result from another goroutine.
+// maxLoadHandoffs bounds how many times load retries a dead leader before
+// giving up and propagating its error. Without a cap, a persistently
+// slow/failing loader (see cache.WithLoaderTimeout) would make every waiter
+// busy-retry singleflight.Do in a tight loop until the caller's own ctx
+// expires – the opposite of what coalescing is supposed to buy: it would
+// amplify load on a struggling backend instead of damping it.
+const maxLoadHandoffs = 8
 
-type LoadResult[V any] struct {
-    Value  V
-    Err    error
-    Shared bool
-}
-
-/*
-   ---------------- loaderGroup ----------------
-*/
+// loadHandoffBackoff is the fixed delay between hand-off retries. Small and
+// constant rather than exponential: a hand-off only happens because the
+// previous leader's ctx already died (see load's doc comment), so the
+// backoff only needs to keep the retry loop from spinning, not to shed load
+// from a healthy backend.
+const loadHandoffBackoff = time.Millisecond
 
 type loaderGroup[K comparable, V any] struct {
     g singleflight.Group
@@ -54,6 +52,17 @@ func newLoaderGroup[K comparable, V any]() *loaderGroup[K, V] {
 // Every waiter receives the same Value / error.  The returned boolean `shared`
 // follows the semantics of x/sync/singleflight (true when another goroutine
 // already ran the function).
+//
+// Whichever goroutine's call to load actually wins the race becomes the
+// leader: fn runs with *that* goroutine's ctx, so a leader whose own ctx is
+// cancelled (or, with cache.WithLoaderTimeout, times out) fails fn for every
+// concurrent waiter even if their own ctx is perfectly healthy. Since the
+// singleflight entry for keyHash is already cleared by the time Do returns,
+// a waiter in that situation hands off leadership by simply retrying: the
+// retry's Do call starts a fresh in-flight call with its own ctx instead of
+// propagating the dead leader's error. Hand-offs are capped at
+// maxLoadHandoffs, with a short fixed backoff between attempts, so a
+// persistently dying leader can't turn coalescing into a busy-retry loop.
 func (lg *loaderGroup[K, V]) load(
     ctx context.Context,
     keyHash uint64,
@@ -61,47 +70,22 @@ func (lg *loaderGroup[K, V]) load(
     fn LoaderFunc[K, V],
 ) (val V, err error, shared bool) {
     k := strconv.FormatUint(keyHash, 16)
-    res, err, shared := lg.g.Do(k, func() (any, error) {
-        return fn(ctx, key)
-    })
-    if ctx.Err() != nil {
-        return val, ctx.Err(), shared
-    }
-    return res.(V), nil, shared
-}
-
-// loadAsync is a convenience wrapper that returns a typed channel delivering
-// LoadResult.  Internally it relies on singleflight.DoChan.
-func (lg *loaderGroup[K, V]) loadAsync(
-    ctx context.Context,
-    keyHash uint64,
-    key K,
-    fn LoaderFunc[K, V],
-) <-chan LoadResult[V] {
-    out := make(chan LoadResult[V], 1)
-    k := strconv.FormatUint(keyHash, 16)
-
-    ch := lg.g.DoChan(k, func() (any, error) {
-        // NOTE: DoChan does not propagate ctx; we handle cancellation below.
-        return fn(context.Background(), key) // loader may still honour ctx itself
-    })
-
-    go func() {
-        select {
-        case res := <-ch:
-            if res.Err != nil {
-                out <- LoadResult[V]{Err: res.Err, Shared: res.Shared}
-            } else {
-                out <- LoadResult[V]{Value: res.Val.(V), Shared: res.Shared}
+    for attempt := 0; ; attempt++ {
+        res, doErr, sh := lg.g.Do(k, func() (any, error) {
+            return fn(ctx, key)
+        })
+        if doErr != nil {
+            isCtxErr := errors.Is(doErr, context.Canceled) || errors.Is(doErr, context.DeadlineExceeded)
+            if isCtxErr && ctx.Err() == nil && attempt < maxLoadHandoffs {
+                select {
+                case <-time.After(loadHandoffBackoff):
+                case <-ctx.Done():
+                    return val, ctx.Err(), sh
+                }
+                continue // the leader's ctx died, not ours – take over
             }
-        case <-ctx.Done():
-            // Context cancelled before load finished.  We do NOT attempt to
-            // cancel the underlying singleflight call – another waiter might
-            // still need the result.  We simply propagate the ctx error.
-            var zero V
-            out <- LoadResult[V]{Value: zero, Err: ctx.Err(), Shared: false}
+            return val, doErr, sh
         }
-        close(out)
-    }()
-    return out
+        return res.(V), nil, sh
+    }
 }
@@ -0,0 +1,52 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "testing"
+)
+
+// TestLoadCapsHandoffRetries covers the chunk2-3 fix: a leader whose ctx
+// keeps dying (e.g. a persistently slow loader under WithLoaderTimeout)
+// used to make load retry singleflight.Do in an uncapped, un-backed-off
+// loop. A healthy caller ctx must still see a bounded number of attempts
+// rather than spinning until its own ctx expires.
+func TestLoadCapsHandoffRetries(t *testing.T) {
+    lg := newLoaderGroup[string, int]()
+
+    var calls int
+    _, err, _ := lg.load(context.Background(), 1, "k", func(ctx context.Context, key string) (int, error) {
+        calls++
+        return 0, context.DeadlineExceeded
+    })
+
+    if !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+    }
+    if want := maxLoadHandoffs + 1; calls != want {
+        t.Fatalf("loader invoked %d times, want %d (maxLoadHandoffs+1)", calls, want)
+    }
+}
+
+// TestLoadHandoffStopsOnCallerCtxDone covers the same fix: once the caller's
+// own ctx is done, load must stop retrying immediately instead of running
+// out its full hand-off budget.
+func TestLoadHandoffStopsOnCallerCtxDone(t *testing.T) {
+    lg := newLoaderGroup[string, int]()
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    var calls int
+    _, err, _ := lg.load(ctx, 1, "k", func(ctx context.Context, key string) (int, error) {
+        calls++
+        return 0, context.DeadlineExceeded
+    })
+
+    if err == nil {
+        t.Fatalf("expected an error once the caller ctx is done")
+    }
+    if calls != 1 {
+        t.Fatalf("loader invoked %d times, want 1 (caller ctx already done)", calls)
+    }
+}
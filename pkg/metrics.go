@@ -4,32 +4,58 @@ package cache
 // can be used with or without metrics.  When the user passes a *prometheus.Registry
 // in New(..., WithMetrics(reg)), we create labeled metrics and expose them via
 // the registry.  Otherwise a no‑op sink is used and the hot‑path does not pay
-// for metric updates.
+// for metric updates. otel.go provides a parallel OpenTelemetry-backed
+// implementation (see WithOTelMeter); newMetricsSink fans out to both when
+// both are configured.
 //
 // All metrics are **shard‑level**; aggregations can easily be done on the
-// Prometheus side via sum() / rate().  We keep the implementation minimal to
-// avoid a hard dependency on any particular monitoring stack.
+// Prometheus side via sum() / rate(), or the OTel collector's equivalent. We
+// keep the implementation minimal to avoid a hard dependency on any
+// particular monitoring stack.
 //
 // Metric names follow Prometheus best practices, suffixed with "_total" for
 // counters.  The `arena_bytes` gauge reflects live arena memory per shard.
 //
-// ┌─────────────────────────────────────┐
-// │ Metric              │ Type │ Labels │
-// ├──────────────────────┼──────┼────────┤
-// │ cache_hits_total     │ Ctr  │ shard  │
-// │ cache_misses_total   │ Ctr  │ shard  │
-// │ cache_evictions_total│ Ctr  │ shard  │
-// │ arena_rotations_total│ Ctr  │ shard  │
-// │ arena_bytes          │ Gge  │ shard  │
-// └─────────────────────────────────────┘
+// ┌──────────────────────────────┐
+// │ Metric                 │ Type │ Labels │
+// ├─────────────────────────┼──────┼────────┤
+// │ cache_hits_total        │ Ctr  │ shard  │
+// │ cache_misses_total      │ Ctr  │ shard  │
+// │ cache_evictions_total   │ Ctr  │ shard  │
+// │ arena_rotations_total   │ Ctr  │ shard  │
+// │ arena_bytes             │ Gge  │ shard  │
+// │ get_duration_seconds    │ Hist │ shard  │
+// │ loader_duration_seconds │ Hist │ shard  │
+// │ put_value_bytes         │ Hist │ shard  │
+// │ arena_rotation_duration_seconds │ Hist │ shard │
+// │ loader_coalesced_total  │ Ctr  │ shard  │
+// │ loader_inflight         │ Gge  │ shard  │
+// │ clock_hot_bytes         │ Gge  │ shard  │
+// │ clock_cold_bytes        │ Gge  │ shard  │
+// │ clock_ghost_bytes       │ Gge  │ shard  │
+// │ clock_hot_target_bytes  │ Gge  │ shard  │
+// │ generations             │ Gge  │ shard  │
+// └──────────────────────────────┘
+//
+// The four histograms are recorded as Prometheus native histograms (sparse,
+// exponential buckets – see HistogramOpts.NativeHistogramBucketFactor) so
+// resolution doesn't cost a fixed, hand-picked bucket list. If the registry
+// refuses the native form, newHistogramVec falls back to an explicit-bucket
+// histogram instead; WithHistogramBuckets overrides the fallback's bucket
+// boundaries. Per-shard Observers are resolved once at construction (see
+// arenaMirror for the same idea applied to the arena_bytes gauge) so the hot
+// path never pays for a WithLabelValues lookup.
 //
 // © 2025 arena-cache authors. MIT License.
 
 import (
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"go.opentelemetry.io/otel/metric"
 )
 
 /*
@@ -41,12 +67,51 @@ import (
 // shards only know about the generic methods here.
 
 type metricsSink interface {
-    incHit(shard uint8)
-    incMiss(shard uint8)
-    incEvict(shard uint8)
+    // incHit/incMiss/incEvict accept an optional exemplar (see
+    // WithExemplarExtractor), attached to the counter increment when the
+    // underlying collector supports prometheus.ExemplarAdder. A nil
+    // exemplar behaves exactly like the pre-exemplar Inc().
+    incHit(shard uint8, exemplar prometheus.Labels)
+    incMiss(shard uint8, exemplar prometheus.Labels)
+    incEvict(shard uint8, exemplar prometheus.Labels)
     incRotation(shard uint8)
     addArenaBytes(shard uint8, delta int64)
     setArenaBytes(shard uint8, value int64)
+
+    // observeGetLatency records how long a Cache.Get call spent on shard,
+    // with an optional exemplar (see WithExemplarExtractor).
+    observeGetLatency(shard uint8, d time.Duration, exemplar prometheus.Labels)
+    // observeLoaderLatency records how long the LoaderFunc invoked by
+    // Cache.GetOrLoad took to run on shard (not recorded on a cache hit, nor
+    // on a call served by a concurrent in-flight loader – see loader.go),
+    // with an optional exemplar (see WithExemplarExtractor).
+    observeLoaderLatency(shard uint8, d time.Duration, exemplar prometheus.Labels)
+    // observePutBytes records the weight argument passed to Cache.Put.
+    observePutBytes(shard uint8, weight int)
+    // observeRotationDuration records how long a single generation rotation
+    // took (see shard.rotate).
+    observeRotationDuration(shard uint8, d time.Duration)
+
+    // incLoaderCoalesced counts a GetOrLoad miss that was served by a
+    // concurrent in-flight loader call instead of running its own (see
+    // cache.WithLoaderCoalescing and loaderGroup).
+    incLoaderCoalesced(shard uint8)
+    // addLoaderInflight adjusts the number of LoaderFunc invocations
+    // currently running on shard; delta is +1 when one starts and -1 when
+    // it finishes.
+    addLoaderInflight(shard uint8, delta int64)
+
+    // setClockSizes mirrors clockpro.Clock.Sizes() for shard onto the
+    // clock_hot_bytes/clock_cold_bytes/clock_ghost_bytes/
+    // clock_hot_target_bytes gauges (see cache.WithHotFraction and
+    // cache.WithTestCapacity), so operators can verify the adaptive policy
+    // is actually behaving as configured.
+    setClockSizes(shard uint8, hot, cold, ghost, target int64)
+
+    // setGenerations mirrors genring.Ring.Generations() for shard onto the
+    // generations gauge, so operators can confirm the autotuner (see
+    // cache.WithGenerations) is converging rather than thrashing.
+    setGenerations(shard uint8, n int64)
 }
 
 /*
@@ -55,12 +120,23 @@ type metricsSink interface {
 
 type noopMetrics struct{}
 
-func (noopMetrics) incHit(uint8)                 {}
-func (noopMetrics) incMiss(uint8)                {}
-func (noopMetrics) incEvict(uint8)               {}
-func (noopMetrics) incRotation(uint8)            {}
-func (noopMetrics) addArenaBytes(uint8, int64)   {}
-func (noopMetrics) setArenaBytes(uint8, int64)   {}
+func (noopMetrics) incHit(uint8, prometheus.Labels)   {}
+func (noopMetrics) incMiss(uint8, prometheus.Labels)  {}
+func (noopMetrics) incEvict(uint8, prometheus.Labels) {}
+func (noopMetrics) incRotation(uint8)                 {}
+func (noopMetrics) addArenaBytes(uint8, int64)        {}
+func (noopMetrics) setArenaBytes(uint8, int64)        {}
+
+func (noopMetrics) observeGetLatency(uint8, time.Duration, prometheus.Labels)    {}
+func (noopMetrics) observeLoaderLatency(uint8, time.Duration, prometheus.Labels) {}
+func (noopMetrics) observePutBytes(uint8, int)                                  {}
+func (noopMetrics) observeRotationDuration(uint8, time.Duration)                 {}
+
+func (noopMetrics) incLoaderCoalesced(uint8)       {}
+func (noopMetrics) addLoaderInflight(uint8, int64) {}
+
+func (noopMetrics) setClockSizes(uint8, int64, int64, int64, int64) {}
+func (noopMetrics) setGenerations(uint8, int64)                     {}
 
 /*
    ---------------- Prometheus implementation ----------------
@@ -76,11 +152,71 @@ type promMetrics struct {
     // For arenas we also keep atomic mirrors so that Rotator can compute delta
     // without calling WithLabelValues() on the hot path.
     arenaMirror []atomic.Int64 // len == shardCount
+
+    // Histograms recording operation latency and value-size distributions
+    // (see WithHistogramBuckets). getLatencyObs/loaderLatencyObs/
+    // putBytesObs/rotationDurationObs are the per-shard Observers resolved
+    // once at construction, indexed directly by shard id, so recording a
+    // sample never calls WithLabelValues on the hot path.
+    getLatency       *prometheus.HistogramVec
+    loaderLatency    *prometheus.HistogramVec
+    putBytes         *prometheus.HistogramVec
+    rotationDuration *prometheus.HistogramVec
+
+    getLatencyObs       []prometheus.Observer
+    loaderLatencyObs    []prometheus.Observer
+    putBytesObs         []prometheus.Observer
+    rotationDurationObs []prometheus.Observer
+
+    // loaderCoalesced/loaderInflight back WithLoaderCoalescing's metrics
+    // (see cache_loader_coalesced_total/cache_loader_inflight); per-shard
+    // Counter/Gauge are resolved once at construction, same rationale as
+    // the *Obs slices above.
+    loaderCoalesced *prometheus.CounterVec
+    loaderInflight  *prometheus.GaugeVec
+
+    loaderCoalescedCtr []prometheus.Counter
+    loaderInflightGauge []prometheus.Gauge
+
+    // clockHot/clockCold/clockGhost/clockTarget back WithHotFraction's and
+    // WithTestCapacity's metrics surface (see setClockSizes); per-shard
+    // Gauges are resolved once at construction, same rationale as the *Obs
+    // slices above.
+    clockHot    *prometheus.GaugeVec
+    clockCold   *prometheus.GaugeVec
+    clockGhost  *prometheus.GaugeVec
+    clockTarget *prometheus.GaugeVec
+
+    clockHotGauge    []prometheus.Gauge
+    clockColdGauge   []prometheus.Gauge
+    clockGhostGauge  []prometheus.Gauge
+    clockTargetGauge []prometheus.Gauge
+
+    // generations backs the autotuner's visibility surface (see
+    // cache.WithGenerations and genring.Ring.Generations); per-shard Gauges
+    // are resolved once at construction, same rationale as the *Obs slices
+    // above.
+    generations      *prometheus.GaugeVec
+    generationsGauge []prometheus.Gauge
 }
 
-func newPromMetrics(shardCount int, reg *prometheus.Registry) *promMetrics {
+// defaultByteBuckets sizes the put_value_bytes fallback histogram from 64B
+// to 16MiB, a range sensible for both small struct weights and byte-sized
+// payload weights.
+func defaultByteBuckets() []float64 {
+    return prometheus.ExponentialBuckets(64, 4, 10)
+}
+
+func newPromMetrics(shardCount int, reg *prometheus.Registry, latencyBuckets, byteBuckets []float64) *promMetrics {
     label := []string{"shard"}
 
+    if latencyBuckets == nil {
+        latencyBuckets = prometheus.DefBuckets
+    }
+    if byteBuckets == nil {
+        byteBuckets = defaultByteBuckets()
+    }
+
     pm := &promMetrics{
         hits: prometheus.NewCounterVec(
             prometheus.CounterOpts{
@@ -113,26 +249,124 @@ func newPromMetrics(shardCount int, reg *prometheus.Registry) *promMetrics {
                 Help:      "Live bytes allocated in arenas.",
             }, label),
         arenaMirror: make([]atomic.Int64, shardCount),
+        loaderCoalesced: prometheus.NewCounterVec(
+            prometheus.CounterOpts{
+                Namespace: "arena_cache",
+                Name:      "loader_coalesced_total",
+                Help:      "Number of GetOrLoad misses served by a concurrent in-flight loader call instead of running their own.",
+            }, label),
+        loaderInflight: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "loader_inflight",
+                Help:      "Number of LoaderFunc invocations currently running.",
+            }, label),
+        clockHot: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "clock_hot_bytes",
+                Help:      "CLOCK-Pro HOT partition size, in caller-defined weight units.",
+            }, label),
+        clockCold: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "clock_cold_bytes",
+                Help:      "CLOCK-Pro COLD partition size, in caller-defined weight units.",
+            }, label),
+        clockGhost: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "clock_ghost_bytes",
+                Help:      "CLOCK-Pro TEST (ghost) partition size, in caller-defined weight units.",
+            }, label),
+        clockTarget: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "clock_hot_target_bytes",
+                Help:      "CLOCK-Pro's adaptive HOT target (see cache.WithHotFraction), in caller-defined weight units.",
+            }, label),
+        generations: prometheus.NewGaugeVec(
+            prometheus.GaugeOpts{
+                Namespace: "arena_cache",
+                Name:      "generations",
+                Help:      "Current genring generation count (see cache.WithGenerations); autotuned unless pinned.",
+            }, label),
     }
 
     // Register collectors. If registry is nil the caller decided to disable
     // metrics; function should never be called with nil.
-    reg.MustRegister(pm.hits, pm.misses, pm.evictions, pm.rotations, pm.arena)
+    reg.MustRegister(pm.hits, pm.misses, pm.evictions, pm.rotations, pm.arena, pm.loaderCoalesced, pm.loaderInflight,
+        pm.clockHot, pm.clockCold, pm.clockGhost, pm.clockTarget, pm.generations)
+
+    pm.getLatency = newHistogramVec(reg, "get_duration_seconds", "Latency of Cache.Get calls.", latencyBuckets)
+    pm.loaderLatency = newHistogramVec(reg, "loader_duration_seconds", "Latency of the user-supplied loader invoked by Cache.GetOrLoad on miss.", latencyBuckets)
+    pm.putBytes = newHistogramVec(reg, "put_value_bytes", "Distribution of the weight argument passed to Cache.Put.", byteBuckets)
+    pm.rotationDuration = newHistogramVec(reg, "arena_rotation_duration_seconds", "Latency of a single generation rotation.", latencyBuckets)
+
+    pm.getLatencyObs = make([]prometheus.Observer, shardCount)
+    pm.loaderLatencyObs = make([]prometheus.Observer, shardCount)
+    pm.putBytesObs = make([]prometheus.Observer, shardCount)
+    pm.rotationDurationObs = make([]prometheus.Observer, shardCount)
+    pm.loaderCoalescedCtr = make([]prometheus.Counter, shardCount)
+    pm.loaderInflightGauge = make([]prometheus.Gauge, shardCount)
+    pm.clockHotGauge = make([]prometheus.Gauge, shardCount)
+    pm.clockColdGauge = make([]prometheus.Gauge, shardCount)
+    pm.clockGhostGauge = make([]prometheus.Gauge, shardCount)
+    pm.clockTargetGauge = make([]prometheus.Gauge, shardCount)
+    pm.generationsGauge = make([]prometheus.Gauge, shardCount)
+    for i := 0; i < shardCount; i++ {
+        shardLabel := strconv.Itoa(i)
+        pm.getLatencyObs[i] = pm.getLatency.WithLabelValues(shardLabel)
+        pm.loaderLatencyObs[i] = pm.loaderLatency.WithLabelValues(shardLabel)
+        pm.putBytesObs[i] = pm.putBytes.WithLabelValues(shardLabel)
+        pm.rotationDurationObs[i] = pm.rotationDuration.WithLabelValues(shardLabel)
+        pm.loaderCoalescedCtr[i] = pm.loaderCoalesced.WithLabelValues(shardLabel)
+        pm.loaderInflightGauge[i] = pm.loaderInflight.WithLabelValues(shardLabel)
+        pm.clockHotGauge[i] = pm.clockHot.WithLabelValues(shardLabel)
+        pm.clockColdGauge[i] = pm.clockCold.WithLabelValues(shardLabel)
+        pm.clockGhostGauge[i] = pm.clockGhost.WithLabelValues(shardLabel)
+        pm.clockTargetGauge[i] = pm.clockTarget.WithLabelValues(shardLabel)
+        pm.generationsGauge[i] = pm.generations.WithLabelValues(shardLabel)
+    }
+
     return pm
 }
 
+// newHistogramVec registers a native-histogram HistogramVec labeled "shard"
+// on reg, falling back to an explicit-bucket histogram using fallbackBuckets
+// if the registry rejects the native form (e.g. an incompatible scrape
+// target downstream).
+func newHistogramVec(reg *prometheus.Registry, name, help string, fallbackBuckets []float64) *prometheus.HistogramVec {
+    opts := prometheus.HistogramOpts{
+        Namespace:                      "arena_cache",
+        Name:                           name,
+        Help:                           help,
+        NativeHistogramBucketFactor:    1.1,
+        NativeHistogramMaxBucketNumber: 100,
+    }
+    hv := prometheus.NewHistogramVec(opts, []string{"shard"})
+    if err := reg.Register(hv); err != nil {
+        opts.NativeHistogramBucketFactor = 0
+        opts.NativeHistogramMaxBucketNumber = 0
+        opts.Buckets = fallbackBuckets
+        hv = prometheus.NewHistogramVec(opts, []string{"shard"})
+        reg.MustRegister(hv)
+    }
+    return hv
+}
+
 /*
    -------- promMetrics implements metricsSink --------
 */
 
-func (m *promMetrics) incHit(shard uint8) {
-    m.hits.WithLabelValues(strconv.Itoa(int(shard))).Inc()
+func (m *promMetrics) incHit(shard uint8, exemplar prometheus.Labels) {
+    addWithExemplar(m.hits.WithLabelValues(strconv.Itoa(int(shard))), exemplar)
 }
-func (m *promMetrics) incMiss(shard uint8) {
-    m.misses.WithLabelValues(strconv.Itoa(int(shard))).Inc()
+func (m *promMetrics) incMiss(shard uint8, exemplar prometheus.Labels) {
+    addWithExemplar(m.misses.WithLabelValues(strconv.Itoa(int(shard))), exemplar)
 }
-func (m *promMetrics) incEvict(shard uint8) {
-    m.evictions.WithLabelValues(strconv.Itoa(int(shard))).Inc()
+func (m *promMetrics) incEvict(shard uint8, exemplar prometheus.Labels) {
+    addWithExemplar(m.evictions.WithLabelValues(strconv.Itoa(int(shard))), exemplar)
 }
 func (m *promMetrics) incRotation(shard uint8) {
     m.rotations.WithLabelValues(strconv.Itoa(int(shard))).Inc()
@@ -146,15 +380,140 @@ func (m *promMetrics) setArenaBytes(shard uint8, value int64) {
     m.arena.WithLabelValues(strconv.Itoa(int(shard))).Set(float64(value))
 }
 
+func (m *promMetrics) observeGetLatency(shard uint8, d time.Duration, exemplar prometheus.Labels) {
+    observeWithExemplar(m.getLatencyObs[shard], d.Seconds(), exemplar)
+}
+func (m *promMetrics) observeLoaderLatency(shard uint8, d time.Duration, exemplar prometheus.Labels) {
+    observeWithExemplar(m.loaderLatencyObs[shard], d.Seconds(), exemplar)
+}
+func (m *promMetrics) observePutBytes(shard uint8, weight int) {
+    m.putBytesObs[shard].Observe(float64(weight))
+}
+func (m *promMetrics) observeRotationDuration(shard uint8, d time.Duration) {
+    m.rotationDurationObs[shard].Observe(d.Seconds())
+}
+
+func (m *promMetrics) incLoaderCoalesced(shard uint8) {
+    m.loaderCoalescedCtr[shard].Inc()
+}
+func (m *promMetrics) addLoaderInflight(shard uint8, delta int64) {
+    m.loaderInflightGauge[shard].Add(float64(delta))
+}
+
+func (m *promMetrics) setClockSizes(shard uint8, hot, cold, ghost, target int64) {
+    m.clockHotGauge[shard].Set(float64(hot))
+    m.clockColdGauge[shard].Set(float64(cold))
+    m.clockGhostGauge[shard].Set(float64(ghost))
+    m.clockTargetGauge[shard].Set(float64(target))
+}
+
+func (m *promMetrics) setGenerations(shard uint8, n int64) {
+    m.generationsGauge[shard].Set(float64(n))
+}
+
+/*
+   -------- exemplar helpers --------
+*/
+
+// addWithExemplar increments c by 1, attaching exemplar when both exemplar
+// is non-nil and c implements prometheus.ExemplarAdder (true for every
+// counter this package creates, but the type assertion keeps us honest
+// about the actual client_golang contract rather than assuming it).
+func addWithExemplar(c prometheus.Counter, exemplar prometheus.Labels) {
+    if exemplar == nil {
+        c.Inc()
+        return
+    }
+    if ea, ok := c.(prometheus.ExemplarAdder); ok {
+        ea.AddWithExemplar(1, exemplar)
+        return
+    }
+    c.Inc()
+}
+
+// observeWithExemplar records value on o, attaching exemplar when both
+// exemplar is non-nil and o implements prometheus.ExemplarObserver.
+func observeWithExemplar(o prometheus.Observer, value float64, exemplar prometheus.Labels) {
+    if exemplar == nil {
+        o.Observe(value)
+        return
+    }
+    if eo, ok := o.(prometheus.ExemplarObserver); ok {
+        eo.ObserveWithExemplar(value, exemplar)
+        return
+    }
+    o.Observe(value)
+}
+
 /*
    ---------------- Factory ----------------
 */
 
-// newMetricsSink decides which implementation to use.  Caller guarantees that
-// shardCount is >0.
-func newMetricsSink(shardCount int, reg *prometheus.Registry) metricsSink {
-    if reg == nil {
+// newMetricsSink decides which implementation(s) to use.  Caller guarantees
+// that shardCount is >0. latencyBuckets/byteBuckets override the
+// explicit-bucket fallback (see WithHistogramBuckets); nil selects the
+// package defaults. reg and meter are independent – either, both, or
+// neither may be set (see WithMetrics and WithOTelMeter) – with both set,
+// every emission site reports to both backends via multiMetrics.
+func newMetricsSink(shardCount int, reg *prometheus.Registry, latencyBuckets, byteBuckets []float64, meter metric.Meter) metricsSink {
+    var prom, otel metricsSink
+    if reg != nil {
+        prom = newPromMetrics(shardCount, reg, latencyBuckets, byteBuckets)
+    }
+    if meter != nil {
+        otel = newOTelMetrics(shardCount, meter)
+    }
+    switch {
+    case prom != nil && otel != nil:
+        return multiMetrics{prom, otel}
+    case prom != nil:
+        return prom
+    case otel != nil:
+        return otel
+    default:
         return noopMetrics{}
     }
-    return newPromMetrics(shardCount, reg)
+}
+
+/*
+   ---------------- Namespace metrics (see Cache.Namespace) ----------------
+*/
+
+// namespaceMetrics mirrors NamespaceStats onto the Prometheus registry
+// configured via WithMetrics, labeled by namespace name rather than shard –
+// counters are aggregated across every shard a namespace touches, since a
+// namespace is not pinned to a single shard.
+type namespaceMetrics struct {
+    hits      *prometheus.CounterVec
+    misses    *prometheus.CounterVec
+    evictions *prometheus.CounterVec
+    bytes     *prometheus.GaugeVec
+}
+
+func newNamespaceMetrics(reg *prometheus.Registry) *namespaceMetrics {
+    label := []string{"ns"}
+    nm := &namespaceMetrics{
+        hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Name:      "namespace_hits_total",
+            Help:      "Number of cache hits scoped to a single Cache.Namespace.",
+        }, label),
+        misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Name:      "namespace_misses_total",
+            Help:      "Number of cache misses scoped to a single Cache.Namespace.",
+        }, label),
+        evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Name:      "namespace_evictions_total",
+            Help:      "Number of Delete calls scoped to a single Cache.Namespace.",
+        }, label),
+        bytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+            Namespace: "arena_cache",
+            Name:      "namespace_bytes",
+            Help:      "Approximate live weight, in caller-defined units, held by a single Cache.Namespace.",
+        }, label),
+    }
+    reg.MustRegister(nm.hits, nm.misses, nm.evictions, nm.bytes)
+    return nm
 }
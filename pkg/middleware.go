@@ -0,0 +1,239 @@
+package cache
+
+// middleware.go introduces a small, http.Handler-style middleware chain
+// around the four Cache operations (Get, Put, Delete, GetOrLoad). Unlike the
+// CLOCK‑Pro / arena machinery, the chain runs at the Cache boundary, not
+// inside shard – so a middleware never sees the hashed key or arena
+// internals, only the same (ctx, key, …) shape a caller of Cache sees. This
+// lets third-party code add tracing, auth, or request coalescing without
+// touching the hot path implemented in shard.go.
+//
+// Middlewares are installed at construction time via Use(...), passed as an
+// Option to New. They wrap in the order given: Use(mw1, mw2) calls mw1 first
+// on every operation, which then calls into mw2, which then calls the real
+// Cache implementation.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+/*
+   ---------------- Operation function types ----------------
+*/
+
+// GetFunc is the shape of Cache.Get – also the type a Middleware wraps.
+type GetFunc[K comparable, V any] func(ctx context.Context, key K) (V, bool)
+
+// PutFunc is the shape of Cache.Put.
+type PutFunc[K comparable, V any] func(ctx context.Context, key K, val V, weight int)
+
+// DeleteFunc is the shape of Cache.Delete.
+type DeleteFunc[K comparable] func(ctx context.Context, key K)
+
+// GetOrLoadFunc is the shape of Cache.GetOrLoad.
+type GetOrLoadFunc[K comparable, V any] func(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error)
+
+// Middleware wraps zero or more Cache operations. A nil Wrap* field leaves
+// the corresponding operation untouched by this middleware.
+//
+// Attach, when non-nil, is invoked once after the Cache is fully constructed
+// so a middleware can start background work that needs the Cache itself
+// (e.g. Sweeper's periodic walk). The returned stop func, if any, is called
+// from Cache.Close.
+type Middleware[K comparable, V any] struct {
+    WrapGet       func(GetFunc[K, V]) GetFunc[K, V]
+    WrapPut       func(PutFunc[K, V]) PutFunc[K, V]
+    WrapDelete    func(DeleteFunc[K]) DeleteFunc[K]
+    WrapGetOrLoad func(GetOrLoadFunc[K, V]) GetOrLoadFunc[K, V]
+    Attach        func(c *Cache[K, V]) (stop func())
+}
+
+// Use installs mws as an Option passed to New. Middlewares wrap in the order
+// given – the first one sees every call first.
+func Use[K comparable, V any](mws ...Middleware[K, V]) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.middlewares = append(c.middlewares, mws...)
+    }
+}
+
+/*
+   ---------------- Built-in: stale-on-error fallback ----------------
+*/
+
+// staleEntry is the per-key record kept by staleOnErrorState.
+type staleEntry[V any] struct {
+    val V
+    at  time.Time
+}
+
+// staleOnErrorState holds StaleOnError's mutable state outside of the
+// Middleware's closures, so its sweep can be exercised directly in tests
+// without waiting on a real ticker.
+type staleOnErrorState[K comparable, V any] struct {
+    mu    sync.Mutex
+    last  map[K]staleEntry[V]
+    grace time.Duration
+}
+
+func (s *staleOnErrorState[K, V]) wrapGetOrLoad(next GetOrLoadFunc[K, V]) GetOrLoadFunc[K, V] {
+    return func(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
+        val, err := next(ctx, key, loader)
+        if err == nil {
+            s.mu.Lock()
+            s.last[key] = staleEntry[V]{val: val, at: time.Now()}
+            s.mu.Unlock()
+            return val, nil
+        }
+
+        s.mu.Lock()
+        se, ok := s.last[key]
+        s.mu.Unlock()
+        if ok && time.Since(se.at) <= s.grace {
+            return se.val, nil
+        }
+        return val, err
+    }
+}
+
+// sweep deletes every entry older than grace, so last stays bounded by the
+// live keyspace instead of growing forever. Called periodically from Attach;
+// exposed on the state type so tests can call it directly instead of waiting
+// on the ticker.
+func (s *staleOnErrorState[K, V]) sweep() {
+    now := time.Now()
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for k, se := range s.last {
+        if now.Sub(se.at) > s.grace {
+            delete(s.last, k)
+        }
+    }
+}
+
+func (s *staleOnErrorState[K, V]) attach(c *Cache[K, V]) (stop func()) {
+    done := make(chan struct{})
+    ticker := time.NewTicker(s.grace)
+    go func() {
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                s.sweep()
+            case <-done:
+                return
+            }
+        }
+    }()
+    return func() { close(done) }
+}
+
+// StaleOnError returns a Middleware that remembers the last successfully
+// loaded value per key and, when GetOrLoad's loader fails, serves that
+// last-known-good value instead of the error – as long as it was recorded
+// within the last grace window. Once grace elapses the error is propagated
+// as usual. Useful for smoothing over brief upstream outages.
+//
+// Attach registers a periodic sweep (ticking every grace) that prunes
+// entries older than grace, so the stale-value map stays bounded by the live
+// keyspace instead of growing forever – see Sweeper, whose tombstone walk
+// this mirrors.
+func StaleOnError[K comparable, V any](grace time.Duration) Middleware[K, V] {
+    s := &staleOnErrorState[K, V]{last: make(map[K]staleEntry[V]), grace: grace}
+    return Middleware[K, V]{
+        WrapGetOrLoad: s.wrapGetOrLoad,
+        Attach:        s.attach,
+    }
+}
+
+/*
+   ---------------- Built-in: per-operation latency metrics ----------------
+*/
+
+// MetricsMiddleware records per-operation latency into a Prometheus
+// histogram registered on reg. Passing a nil registry returns a Middleware
+// with no wraps, so it is always safe to install.
+func MetricsMiddleware[K comparable, V any](reg *prometheus.Registry) Middleware[K, V] {
+    if reg == nil {
+        return Middleware[K, V]{}
+    }
+
+    latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+        Namespace: "arena_cache",
+        Name:      "op_duration_seconds",
+        Help:      "Latency of Cache operations observed at the middleware boundary.",
+        Buckets:   prometheus.DefBuckets,
+    }, []string{"op"})
+    reg.MustRegister(latency)
+
+    observe := func(op string, start time.Time) {
+        latency.WithLabelValues(op).Observe(time.Since(start).Seconds())
+    }
+
+    return Middleware[K, V]{
+        WrapGet: func(next GetFunc[K, V]) GetFunc[K, V] {
+            return func(ctx context.Context, key K) (V, bool) {
+                defer observe("get", time.Now())
+                return next(ctx, key)
+            }
+        },
+        WrapPut: func(next PutFunc[K, V]) PutFunc[K, V] {
+            return func(ctx context.Context, key K, val V, weight int) {
+                defer observe("put", time.Now())
+                next(ctx, key, val, weight)
+            }
+        },
+        WrapDelete: func(next DeleteFunc[K]) DeleteFunc[K] {
+            return func(ctx context.Context, key K) {
+                defer observe("delete", time.Now())
+                next(ctx, key)
+            }
+        },
+        WrapGetOrLoad: func(next GetOrLoadFunc[K, V]) GetOrLoadFunc[K, V] {
+            return func(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
+                defer observe("get_or_load", time.Now())
+                return next(ctx, key, loader)
+            }
+        },
+    }
+}
+
+/*
+   ---------------- Built-in: background GC sweeper ----------------
+*/
+
+// Sweeper returns a Middleware that periodically walks every shard to delete
+// tombstones (see WithNegativeTTL) whose TTL has elapsed, and – when
+// rotateIdle is set – rotates the generation ring of any shard that held no
+// items at the start of the tick, so an idle cache releases arena memory
+// promptly instead of waiting for the next Put to trigger rotation.
+func Sweeper[K comparable, V any](interval time.Duration, rotateIdle bool) Middleware[K, V] {
+    return Middleware[K, V]{
+        Attach: func(c *Cache[K, V]) (stop func()) {
+            done := make(chan struct{})
+            ticker := time.NewTicker(interval)
+            go func() {
+                defer ticker.Stop()
+                for {
+                    select {
+                    case <-ticker.C:
+                        for _, sh := range c.shards {
+                            sh.sweepTombstones()
+                            if rotateIdle {
+                                sh.rotateIfIdle()
+                            }
+                        }
+                    case <-done:
+                        return
+                    }
+                }
+            }()
+            return func() { close(done) }
+        },
+    }
+}
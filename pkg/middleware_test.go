@@ -0,0 +1,30 @@
+package cache
+
+import (
+    "testing"
+    "time"
+)
+
+// TestStaleOnErrorSweepPrunesExpiredEntries covers the chunk0-4 fix:
+// staleOnErrorState.last recorded an entry for every successfully-loaded key
+// and nothing ever deleted it, leaking memory unboundedly for a long-lived
+// cache with a large keyspace. sweep (wired to Attach's ticker) now reclaims
+// entries once they are older than grace.
+func TestStaleOnErrorSweepPrunesExpiredEntries(t *testing.T) {
+    s := &staleOnErrorState[string, int]{
+        last:  make(map[string]staleEntry[int]),
+        grace: 10 * time.Millisecond,
+    }
+
+    s.last["fresh"] = staleEntry[int]{val: 1, at: time.Now()}
+    s.last["stale"] = staleEntry[int]{val: 2, at: time.Now().Add(-time.Hour)}
+
+    s.sweep()
+
+    if _, ok := s.last["stale"]; ok {
+        t.Fatalf("sweep left an entry older than grace in place")
+    }
+    if _, ok := s.last["fresh"]; !ok {
+        t.Fatalf("sweep removed an entry that was still within grace")
+    }
+}
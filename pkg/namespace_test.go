@@ -0,0 +1,58 @@
+package cache
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// TestNamespaceGetOrLoadStats covers the chunk0-5 fix: unlike getFn/putFn,
+// getOrLoadFn used to be a bare pass-through to shard.getOrLoad with none of
+// the ns.nsHits/nsMisses/nsBytes bookkeeping the other two closures do.
+// Since GetOrLoad is the primary entry point for most real usage, every
+// namespace's stats silently stayed at zero for it.
+func TestNamespaceGetOrLoadStats(t *testing.T) {
+    c, err := New[string, int](1<<20, time.Hour, 1)
+    if err != nil {
+        t.Fatalf("New: %v", err)
+    }
+    ns := c.Namespace("tenant-a")
+    ctx := context.Background()
+
+    // First call misses and loads: nsMisses and nsBytes should move.
+    v, err := ns.GetOrLoad(ctx, "k", func(ctx context.Context, key string) (int, error) {
+        return 42, nil
+    })
+    if err != nil || v != 42 {
+        t.Fatalf("GetOrLoad() = %v, %v; want 42, nil", v, err)
+    }
+
+    stats, ok := c.NamespaceStats("tenant-a")
+    if !ok {
+        t.Fatalf("expected namespace stats to exist")
+    }
+    if stats.Misses != 1 {
+        t.Fatalf("Misses = %d, want 1", stats.Misses)
+    }
+    if stats.Bytes == 0 {
+        t.Fatalf("Bytes = 0, want > 0 after a successful load")
+    }
+
+    // Second call for the same key is now a cache hit: nsHits should move,
+    // the loader must not run again.
+    _, err = ns.GetOrLoad(ctx, "k", func(ctx context.Context, key string) (int, error) {
+        t.Fatalf("loader should not run on a cache hit")
+        return 0, nil
+    })
+    if err != nil {
+        t.Fatalf("GetOrLoad() error = %v", err)
+    }
+
+    stats, _ = c.NamespaceStats("tenant-a")
+    if stats.Hits != 1 {
+        t.Fatalf("Hits = %d, want 1", stats.Hits)
+    }
+    if stats.Misses != 1 {
+        t.Fatalf("Misses = %d, want still 1", stats.Misses)
+    }
+}
@@ -0,0 +1,271 @@
+package cache
+
+// otel.go is the OpenTelemetry counterpart to metrics.go: WithMetrics wires a
+// *prometheus.Registry into every shard via the metricsSink interface;
+// WithOTelMeter/WithOTelTracer do the same for callers on the OpenTelemetry
+// stack, without arena-cache depending on client_golang to get there. The two
+// backends are independent – either, both, or neither may be configured (see
+// newMetricsSink and multiMetrics below) – and otelMetrics only depends on
+// go.opentelemetry.io/otel's lightweight API packages, never its SDK.
+//
+// WithOTelTracer is unrelated to metricsSink: it wraps Cache.Get, Cache.Put
+// and shard.rotate in spans so a request's cache interaction shows up
+// alongside the rest of its trace. Get/Put already carry a context.Context to
+// hang the span off; rotate runs either inline from put() or on the Sweeper
+// middleware's timer, neither of which hands it a caller context, and so
+// starts its own context.Background() span instead.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithOTelMeter enables OpenTelemetry metrics collection alongside, or
+// instead of, WithMetrics: hits/misses/evictions/rotations, the
+// latency/value-size histograms, and the CLOCK-Pro gauges (see
+// WithHotFraction, WithTestCapacity) are all recorded through meter too, each
+// tagged with a "shard" attribute mirroring Prometheus's "shard" label.
+// Passing nil disables it (default). When both WithMetrics and WithOTelMeter
+// are configured, every emission site reports to both backends.
+func WithOTelMeter[K comparable, V any](meter metric.Meter) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.otelMeter = meter
+    }
+}
+
+// WithOTelTracer enables tracing spans around Cache.Get, Cache.Put and the
+// background generation rotation (see shard.rotate): tp.Tracer is used to
+// start "arena_cache.get"/"arena_cache.put"/"arena_cache.rotate" spans,
+// letting a cache call show up in whatever trace its caller's context
+// belongs to. Passing nil disables it (default).
+func WithOTelTracer[K comparable, V any](tp trace.TracerProvider) Option[K, V] {
+    return func(c *config[K, V]) {
+        c.otelTracerProvider = tp
+    }
+}
+
+/*
+   ---------------- OpenTelemetry metricsSink implementation ----------------
+*/
+
+// otelMetrics mirrors promMetrics's shape onto the OpenTelemetry metric API:
+// counters and histograms map directly onto their OTel equivalents; the
+// gauge-shaped series (arena_bytes, loader_inflight, the clock_* sizes) are
+// backed by Int64ObservableGauge instruments fed from atomic mirrors via a
+// single registered callback, since OTel has no synchronous "Set" gauge –
+// the same rationale arenaMirror documents for promMetrics, just pushed one
+// layer further because async instruments are collected on the exporter's
+// schedule rather than read back on demand.
+type otelMetrics struct {
+    hits            metric.Int64Counter
+    misses          metric.Int64Counter
+    evictions       metric.Int64Counter
+    rotations       metric.Int64Counter
+    loaderCoalesced metric.Int64Counter
+
+    getLatency       metric.Float64Histogram
+    loaderLatency    metric.Float64Histogram
+    putBytes         metric.Float64Histogram
+    rotationDuration metric.Float64Histogram
+
+    arenaMirror          []atomic.Int64
+    loaderInflightMirror []atomic.Int64
+    clockHotMirror       []atomic.Int64
+    clockColdMirror      []atomic.Int64
+    clockGhostMirror     []atomic.Int64
+    clockTargetMirror    []atomic.Int64
+    generationsMirror    []atomic.Int64
+}
+
+func newOTelMetrics(shardCount int, meter metric.Meter) *otelMetrics {
+    m := &otelMetrics{
+        arenaMirror:          make([]atomic.Int64, shardCount),
+        loaderInflightMirror: make([]atomic.Int64, shardCount),
+        clockHotMirror:       make([]atomic.Int64, shardCount),
+        clockColdMirror:      make([]atomic.Int64, shardCount),
+        clockGhostMirror:     make([]atomic.Int64, shardCount),
+        clockTargetMirror:    make([]atomic.Int64, shardCount),
+        generationsMirror:    make([]atomic.Int64, shardCount),
+    }
+
+    m.hits = otelMust(meter.Int64Counter("arena_cache.hits", metric.WithDescription("Number of cache hits.")))
+    m.misses = otelMust(meter.Int64Counter("arena_cache.misses", metric.WithDescription("Number of cache misses.")))
+    m.evictions = otelMust(meter.Int64Counter("arena_cache.evictions", metric.WithDescription("Number of items evicted by CLOCK-Pro.")))
+    m.rotations = otelMust(meter.Int64Counter("arena_cache.arena_rotations", metric.WithDescription("Number of arena rotations (TTL or capacity).")))
+    m.loaderCoalesced = otelMust(meter.Int64Counter("arena_cache.loader_coalesced", metric.WithDescription("Number of GetOrLoad misses served by a concurrent in-flight loader call instead of running their own.")))
+
+    m.getLatency = otelMust(meter.Float64Histogram("arena_cache.get_duration", metric.WithUnit("s"), metric.WithDescription("Latency of Cache.Get calls.")))
+    m.loaderLatency = otelMust(meter.Float64Histogram("arena_cache.loader_duration", metric.WithUnit("s"), metric.WithDescription("Latency of the user-supplied loader invoked by Cache.GetOrLoad on miss.")))
+    m.putBytes = otelMust(meter.Float64Histogram("arena_cache.put_value_bytes", metric.WithDescription("Distribution of the weight argument passed to Cache.Put.")))
+    m.rotationDuration = otelMust(meter.Float64Histogram("arena_cache.arena_rotation_duration", metric.WithUnit("s"), metric.WithDescription("Latency of a single generation rotation.")))
+
+    arenaGauge := otelMust(meter.Int64ObservableGauge("arena_cache.arena_bytes", metric.WithDescription("Live bytes allocated in arenas.")))
+    loaderInflightGauge := otelMust(meter.Int64ObservableGauge("arena_cache.loader_inflight", metric.WithDescription("Number of LoaderFunc invocations currently running.")))
+    clockHotGauge := otelMust(meter.Int64ObservableGauge("arena_cache.clock_hot_bytes", metric.WithDescription("CLOCK-Pro HOT partition size, in caller-defined weight units.")))
+    clockColdGauge := otelMust(meter.Int64ObservableGauge("arena_cache.clock_cold_bytes", metric.WithDescription("CLOCK-Pro COLD partition size, in caller-defined weight units.")))
+    clockGhostGauge := otelMust(meter.Int64ObservableGauge("arena_cache.clock_ghost_bytes", metric.WithDescription("CLOCK-Pro TEST (ghost) partition size, in caller-defined weight units.")))
+    clockTargetGauge := otelMust(meter.Int64ObservableGauge("arena_cache.clock_hot_target_bytes", metric.WithDescription("CLOCK-Pro's adaptive HOT target (see cache.WithHotFraction), in caller-defined weight units.")))
+    generationsGauge := otelMust(meter.Int64ObservableGauge("arena_cache.generations", metric.WithDescription("Current genring generation count (see cache.WithGenerations); autotuned unless pinned.")))
+
+    _, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+        for i := 0; i < shardCount; i++ {
+            attrs := metric.WithAttributes(attribute.Int("shard", i))
+            o.ObserveInt64(arenaGauge, m.arenaMirror[i].Load(), attrs)
+            o.ObserveInt64(loaderInflightGauge, m.loaderInflightMirror[i].Load(), attrs)
+            o.ObserveInt64(clockHotGauge, m.clockHotMirror[i].Load(), attrs)
+            o.ObserveInt64(clockColdGauge, m.clockColdMirror[i].Load(), attrs)
+            o.ObserveInt64(clockGhostGauge, m.clockGhostMirror[i].Load(), attrs)
+            o.ObserveInt64(clockTargetGauge, m.clockTargetMirror[i].Load(), attrs)
+            o.ObserveInt64(generationsGauge, m.generationsMirror[i].Load(), attrs)
+        }
+        return nil
+    }, arenaGauge, loaderInflightGauge, clockHotGauge, clockColdGauge, clockGhostGauge, clockTargetGauge, generationsGauge)
+    if err != nil {
+        panic(err)
+    }
+
+    return m
+}
+
+// otelMust panics on err, mirroring reg.MustRegister's "fail fast at
+// construction, never on the hot path" contract for Prometheus collectors –
+// OTel's instrument constructors return an error instead of panicking
+// themselves, but the only realistic cause (a malformed instrument name) is
+// a programmer error in this file, not something callers can recover from.
+func otelMust[T any](inst T, err error) T {
+    if err != nil {
+        panic(err)
+    }
+    return inst
+}
+
+func (m *otelMetrics) incHit(shard uint8, _ prometheus.Labels) {
+    m.hits.Add(context.Background(), 1, metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) incMiss(shard uint8, _ prometheus.Labels) {
+    m.misses.Add(context.Background(), 1, metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) incEvict(shard uint8, _ prometheus.Labels) {
+    m.evictions.Add(context.Background(), 1, metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) incRotation(shard uint8) {
+    m.rotations.Add(context.Background(), 1, metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) addArenaBytes(shard uint8, delta int64) {
+    m.arenaMirror[shard].Add(delta)
+}
+func (m *otelMetrics) setArenaBytes(shard uint8, value int64) {
+    m.arenaMirror[shard].Store(value)
+}
+
+func (m *otelMetrics) observeGetLatency(shard uint8, d time.Duration, _ prometheus.Labels) {
+    m.getLatency.Record(context.Background(), d.Seconds(), metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) observeLoaderLatency(shard uint8, d time.Duration, _ prometheus.Labels) {
+    m.loaderLatency.Record(context.Background(), d.Seconds(), metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) observePutBytes(shard uint8, weight int) {
+    m.putBytes.Record(context.Background(), float64(weight), metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) observeRotationDuration(shard uint8, d time.Duration) {
+    m.rotationDuration.Record(context.Background(), d.Seconds(), metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+
+func (m *otelMetrics) incLoaderCoalesced(shard uint8) {
+    m.loaderCoalesced.Add(context.Background(), 1, metric.WithAttributes(attribute.Int("shard", int(shard))))
+}
+func (m *otelMetrics) addLoaderInflight(shard uint8, delta int64) {
+    m.loaderInflightMirror[shard].Add(delta)
+}
+
+func (m *otelMetrics) setClockSizes(shard uint8, hot, cold, ghost, target int64) {
+    m.clockHotMirror[shard].Store(hot)
+    m.clockColdMirror[shard].Store(cold)
+    m.clockGhostMirror[shard].Store(ghost)
+    m.clockTargetMirror[shard].Store(target)
+}
+
+func (m *otelMetrics) setGenerations(shard uint8, n int64) {
+    m.generationsMirror[shard].Store(n)
+}
+
+/*
+   ---------------- Fan-out between Prometheus and OpenTelemetry ----------------
+*/
+
+// multiMetrics fans every metricsSink call out to two backends, so a Cache
+// built with both WithMetrics and WithOTelMeter reports to each
+// independently; see newMetricsSink.
+type multiMetrics struct {
+    a, b metricsSink
+}
+
+func (m multiMetrics) incHit(shard uint8, exemplar prometheus.Labels) {
+    m.a.incHit(shard, exemplar)
+    m.b.incHit(shard, exemplar)
+}
+func (m multiMetrics) incMiss(shard uint8, exemplar prometheus.Labels) {
+    m.a.incMiss(shard, exemplar)
+    m.b.incMiss(shard, exemplar)
+}
+func (m multiMetrics) incEvict(shard uint8, exemplar prometheus.Labels) {
+    m.a.incEvict(shard, exemplar)
+    m.b.incEvict(shard, exemplar)
+}
+func (m multiMetrics) incRotation(shard uint8) {
+    m.a.incRotation(shard)
+    m.b.incRotation(shard)
+}
+func (m multiMetrics) addArenaBytes(shard uint8, delta int64) {
+    m.a.addArenaBytes(shard, delta)
+    m.b.addArenaBytes(shard, delta)
+}
+func (m multiMetrics) setArenaBytes(shard uint8, value int64) {
+    m.a.setArenaBytes(shard, value)
+    m.b.setArenaBytes(shard, value)
+}
+
+func (m multiMetrics) observeGetLatency(shard uint8, d time.Duration, exemplar prometheus.Labels) {
+    m.a.observeGetLatency(shard, d, exemplar)
+    m.b.observeGetLatency(shard, d, exemplar)
+}
+func (m multiMetrics) observeLoaderLatency(shard uint8, d time.Duration, exemplar prometheus.Labels) {
+    m.a.observeLoaderLatency(shard, d, exemplar)
+    m.b.observeLoaderLatency(shard, d, exemplar)
+}
+func (m multiMetrics) observePutBytes(shard uint8, weight int) {
+    m.a.observePutBytes(shard, weight)
+    m.b.observePutBytes(shard, weight)
+}
+func (m multiMetrics) observeRotationDuration(shard uint8, d time.Duration) {
+    m.a.observeRotationDuration(shard, d)
+    m.b.observeRotationDuration(shard, d)
+}
+
+func (m multiMetrics) incLoaderCoalesced(shard uint8) {
+    m.a.incLoaderCoalesced(shard)
+    m.b.incLoaderCoalesced(shard)
+}
+func (m multiMetrics) addLoaderInflight(shard uint8, delta int64) {
+    m.a.addLoaderInflight(shard, delta)
+    m.b.addLoaderInflight(shard, delta)
+}
+
+func (m multiMetrics) setClockSizes(shard uint8, hot, cold, ghost, target int64) {
+    m.a.setClockSizes(shard, hot, cold, ghost, target)
+    m.b.setClockSizes(shard, hot, cold, ghost, target)
+}
+
+func (m multiMetrics) setGenerations(shard uint8, n int64) {
+    m.a.setGenerations(shard, n)
+    m.b.setGenerations(shard, n)
+}
@@ -0,0 +1,153 @@
+package cache
+
+// partition.go lets several Cache[K,V] instances in the same process – of
+// differing K,V type parameters, even – share one overall memory budget
+// instead of each reserving its own capBytes independently. A Cache opts in
+// with WithPartition(id) at construction time and is then admitted to a
+// PartitionManager via Register; the manager periodically redistributes its
+// capBytes ceiling across every Registered partition in proportion to
+// observed hit rate, on the theory that bytes spent on a partition that is
+// mostly missing are better spent on one that is mostly hitting.
+//
+// PartitionManager only ever touches capacity, through the same
+// Cache.Reconfigure path WithCapacity uses directly – there is no separate
+// eviction mechanism here. Shrinking a partition's share goes through
+// shard.reconfigure -> clockpro.Clock.SetCapacity, which forces the same
+// immediate eviction sweep Reconfigure already gives any caller who lowers
+// WithCapacity by hand (see cache.go's partitionSetCapacity).
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// partitionMember is the type-parameter-free surface PartitionManager needs
+// from a Cache[K,V]. Register type-erases the concrete Cache behind it, so
+// one manager can hold partitions of differing K,V – the manager itself
+// never needs to know either.
+type partitionMember interface {
+    partitionID() int
+    partitionHitRate() float64
+    partitionCapacity() int64
+    partitionSetCapacity(capBytes int64) error
+}
+
+type partitionEntry struct {
+    id       int
+    member   partitionMember
+    capBytes int64
+}
+
+// PartitionManager enforces a single capBytes ceiling across every Cache
+// Registered with it, shifting budget from the least-productive partitions
+// (lowest hit rate) to the most productive ones on every Redistribute tick.
+// Construct one with NewPartitionManager; the zero value is not usable.
+type PartitionManager struct {
+    mu       sync.Mutex
+    capBytes int64
+    members  map[int]*partitionEntry
+
+    stop chan struct{}
+}
+
+// NewPartitionManager creates a manager enforcing capBytes as the combined
+// ceiling across every partition later admitted via Register, rebalancing
+// shares every interval based on each partition's hit rate (see
+// Redistribute). Call Close to stop the background rebalancer when the
+// manager is no longer needed.
+func NewPartitionManager(capBytes int64, interval time.Duration) *PartitionManager {
+    pm := &PartitionManager{
+        capBytes: capBytes,
+        members:  make(map[int]*partitionEntry),
+        stop:     make(chan struct{}),
+    }
+    go func() {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                pm.Redistribute()
+            case <-pm.stop:
+                return
+            }
+        }
+    }()
+    return pm
+}
+
+// Register admits c into pm as partition id, which must be the same id c was
+// constructed with via WithPartition – ErrPartitionMismatch otherwise. id
+// must not already be Registered on pm – ErrPartitionExists otherwise. c
+// keeps its existing capBytes until the next Redistribute tick recomputes
+// every partition's share.
+func Register[K comparable, V any](pm *PartitionManager, id int, c *Cache[K, V]) error {
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+
+    if got := c.partitionID(); got != id {
+        return fmt.Errorf("%w: Register(%d, ...) called on a Cache constructed with WithPartition(%d)", ErrPartitionMismatch, id, got)
+    }
+    if _, exists := pm.members[id]; exists {
+        return fmt.Errorf("%w: partition %d", ErrPartitionExists, id)
+    }
+
+    pm.members[id] = &partitionEntry{id: id, member: c, capBytes: c.partitionCapacity()}
+    return nil
+}
+
+// Redistribute recomputes every Registered partition's share of capBytes in
+// proportion to its hit rate and pushes any change out via
+// partitionSetCapacity. Partitions with no traffic yet (hit rate 0 across
+// the board) split capBytes evenly until real data arrives. Called
+// automatically every interval passed to NewPartitionManager; exported so
+// callers can also force an off-cycle rebalance, e.g. right after a burst of
+// Register calls.
+func (pm *PartitionManager) Redistribute() {
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+    pm.rebalanceLocked()
+}
+
+func (pm *PartitionManager) rebalanceLocked() {
+    n := len(pm.members)
+    if n == 0 {
+        return
+    }
+
+    rates := make(map[int]float64, n)
+    var totalRate float64
+    for id, e := range pm.members {
+        r := e.member.partitionHitRate()
+        rates[id] = r
+        totalRate += r
+    }
+
+    equalShare := pm.capBytes / int64(n)
+    for id, e := range pm.members {
+        share := equalShare
+        if totalRate > 0 {
+            share = int64(rates[id] / totalRate * float64(pm.capBytes))
+        }
+        if share <= 0 {
+            share = 1 // never starve a partition down to zero capacity
+        }
+        if share == e.capBytes {
+            continue
+        }
+        if err := e.member.partitionSetCapacity(share); err != nil {
+            continue // leave it at its previous share; retried next tick
+        }
+        e.capBytes = share
+    }
+}
+
+// Close stops pm's background rebalancer. Registered partitions keep
+// whatever share they last held; Close never touches a Cache's capacity or
+// calls its Close.
+func (pm *PartitionManager) Close() {
+    close(pm.stop)
+}
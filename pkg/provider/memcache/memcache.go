@@ -0,0 +1,82 @@
+// Package memcache adapts a Memcached client to the provider.Provider
+// interface so it can back an arena-cache instance as an L2 tier via
+// cache.WithFallback.
+//
+// © 2025 arena-cache authors. MIT License.
+package memcache
+
+import (
+	"context"
+	"errors"
+
+	gomemcache "github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/Voskan/arena-cache/pkg/provider"
+)
+
+// Codec converts values to/from the byte representation stored in Memcached.
+type Codec[V any] interface {
+    Encode(V) ([]byte, error)
+    Decode([]byte) (V, error)
+}
+
+// KeyFunc renders a cache key as a Memcached key (max 250 bytes, no spaces).
+type KeyFunc[K comparable] func(K) string
+
+// Store is a provider.Provider backed by a Memcached client.
+type Store[K comparable, V any] struct {
+    client *gomemcache.Client
+    key    KeyFunc[K]
+    codec  Codec[V]
+}
+
+// New constructs a Memcached-backed provider.
+func New[K comparable, V any](client *gomemcache.Client, key KeyFunc[K], codec Codec[V]) *Store[K, V] {
+    return &Store[K, V]{client: client, key: key, codec: codec}
+}
+
+// Get implements provider.Provider.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    item, err := s.client.Get(s.key(key))
+    if errors.Is(err, gomemcache.ErrCacheMiss) {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+    v, err := s.codec.Decode(item.Value)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements provider.Provider. ttl is nanoseconds; Memcached expects
+// whole seconds so sub-second values round up to 1s.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V, weight int, ttl int64) error {
+    raw, err := s.codec.Encode(val)
+    if err != nil {
+        return err
+    }
+    expSeconds := int32(ttl / 1e9)
+    if ttl > 0 && expSeconds == 0 {
+        expSeconds = 1
+    }
+    return s.client.Set(&gomemcache.Item{Key: s.key(key), Value: raw, Expiration: expSeconds})
+}
+
+// Delete implements provider.Provider.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    err := s.client.Delete(s.key(key))
+    if errors.Is(err, gomemcache.ErrCacheMiss) {
+        return nil
+    }
+    return err
+}
+
+// Close implements provider.Provider. gomemcache.Client has no explicit
+// close; connections are pooled and closed on GC, so this is a no-op.
+func (s *Store[K, V]) Close() error { return nil }
+
+var _ provider.Provider[string, []byte] = (*Store[string, []byte])(nil)
@@ -0,0 +1,35 @@
+// Package provider defines the L2 backend abstraction used to layer
+// arena-cache in front of slower, larger, or shared stores (Redis,
+// Memcached, Ristretto, an on-disk Pebble/bbolt tier, …).
+//
+// arena-cache itself stays a single-process, GC-free L1.  Provider lets that
+// L1 be composed with an L2 without the core package taking a hard
+// dependency on any particular backend — adapters live in their own
+// sub-packages (pkg/provider/redis, pkg/provider/memcache, …) and are only
+// pulled in by applications that actually use them.
+//
+// The interface intentionally mirrors the shape used by go-4devs/cache so
+// that existing L2 adapters are easy to port.
+//
+// © 2025 arena-cache authors. MIT License.
+package provider
+
+import "context"
+
+// Provider is an L2 store that can back a Cache[K,V] via WithFallback.
+// Implementations must be safe for concurrent use.
+type Provider[K comparable, V any] interface {
+    // Get fetches a value for key. The bool reports whether it was present;
+    // a miss is not an error.
+    Get(ctx context.Context, key K) (V, bool, error)
+
+    // Set stores a value for key with the given weight (same unit as the
+    // cache's WeightFn) and ttl (zero means "use the provider's default").
+    Set(ctx context.Context, key K, val V, weight int, ttl int64) error
+
+    // Delete removes key from the provider, if present.
+    Delete(ctx context.Context, key K) error
+
+    // Close releases resources held by the provider (connections, files…).
+    Close() error
+}
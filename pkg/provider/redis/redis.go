@@ -0,0 +1,85 @@
+// Package redis adapts a Redis client to the provider.Provider interface so
+// it can back an arena-cache instance as an L2 tier via cache.WithFallback.
+//
+// Values are opaque to Redis, so callers must supply a Codec capable of
+// turning V into bytes and back; arena-cache ships no default codec because
+// the right choice (JSON, gob, protobuf…) is application specific.
+//
+// © 2025 arena-cache authors. MIT License.
+package redis
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/Voskan/arena-cache/pkg/provider"
+)
+
+// Codec converts values to/from the byte representation stored in Redis.
+type Codec[V any] interface {
+    Encode(V) ([]byte, error)
+    Decode([]byte) (V, error)
+}
+
+// KeyFunc renders a cache key as a Redis key string.
+type KeyFunc[K comparable] func(K) string
+
+// Store is a provider.Provider backed by a Redis client.
+type Store[K comparable, V any] struct {
+    client *goredis.Client
+    key    KeyFunc[K]
+    codec  Codec[V]
+    prefix string
+}
+
+// New constructs a Redis-backed provider. prefix is prepended to every key to
+// namespace the keyspace when a Redis instance is shared by several caches.
+func New[K comparable, V any](client *goredis.Client, key KeyFunc[K], codec Codec[V], prefix string) *Store[K, V] {
+    return &Store[K, V]{client: client, key: key, codec: codec, prefix: prefix}
+}
+
+func (s *Store[K, V]) fullKey(key K) string {
+    return s.prefix + s.key(key)
+}
+
+// Get implements provider.Provider.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    raw, err := s.client.Get(ctx, s.fullKey(key)).Bytes()
+    if err == goredis.Nil {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+    v, err := s.codec.Decode(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements provider.Provider. ttl is in nanoseconds, zero means "no
+// expiry" from arena-cache's point of view but we still apply Redis's own
+// idle-eviction policy if configured server-side.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V, weight int, ttl int64) error {
+    raw, err := s.codec.Encode(val)
+    if err != nil {
+        return err
+    }
+    return s.client.Set(ctx, s.fullKey(key), raw, time.Duration(ttl)).Err()
+}
+
+// Delete implements provider.Provider.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    return s.client.Del(ctx, s.fullKey(key)).Err()
+}
+
+// Close implements provider.Provider.
+func (s *Store[K, V]) Close() error {
+    return s.client.Close()
+}
+
+var _ provider.Provider[string, []byte] = (*Store[string, []byte])(nil)
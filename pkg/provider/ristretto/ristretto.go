@@ -0,0 +1,63 @@
+// Package ristretto adapts a dgraph-io/ristretto cache to the
+// provider.Provider interface. Unlike the Redis/Memcached adapters this one
+// is in-process, so it is mainly useful as a bigger, GC-managed L2 sitting
+// behind arena-cache's GC-free L1 — e.g. to hold values too large or too
+// numerous to keep entirely off-heap.
+//
+// © 2025 arena-cache authors. MIT License.
+package ristretto
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+
+	"github.com/Voskan/arena-cache/pkg/provider"
+)
+
+// KeyFunc renders a cache key as the string Ristretto hashes internally.
+type KeyFunc[K comparable] func(K) string
+
+// Store is a provider.Provider backed by an in-process Ristretto cache.
+type Store[K comparable, V any] struct {
+    cache *ristretto.Cache[string, V]
+    key   KeyFunc[K]
+}
+
+// New constructs a Ristretto-backed provider around an already-configured
+// *ristretto.Cache (NumCounters/MaxCost/BufferItems are app-specific).
+func New[K comparable, V any](cache *ristretto.Cache[string, V], key KeyFunc[K]) *Store[K, V] {
+    return &Store[K, V]{cache: cache, key: key}
+}
+
+// Get implements provider.Provider.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    v, ok := s.cache.Get(s.key(key))
+    return v, ok, nil
+}
+
+// Set implements provider.Provider. ttl is nanoseconds; zero means Ristretto's
+// default (no expiry).
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V, weight int, ttl int64) error {
+    if ttl > 0 {
+        s.cache.SetWithTTL(s.key(key), val, int64(weight), time.Duration(ttl))
+        return nil
+    }
+    s.cache.Set(s.key(key), val, int64(weight))
+    return nil
+}
+
+// Delete implements provider.Provider.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    s.cache.Del(s.key(key))
+    return nil
+}
+
+// Close implements provider.Provider.
+func (s *Store[K, V]) Close() error {
+    s.cache.Close()
+    return nil
+}
+
+var _ provider.Provider[string, int] = (*Store[string, int])(nil)
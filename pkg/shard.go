@@ -1,6 +1,10 @@
 package cache
 
-import "context"
+import (
+	"context"
+	"time"
+	"unsafe"
+)
 
 // shard.go contains the sharded segment of arena‑cache. A Cache is split into N
 // independent shards to minimise lock contention.  Each shard keeps its own
@@ -20,14 +24,188 @@ import "context"
 // short critical sections protected by the RWMutex, all operations are
 // lock‑free thanks to atomic primitives implemented in internal/clockpro.
 
-// getOrLoad retrieves a value from the shard or loads it using the provided loader function.
-func (s *shard[K, V]) getOrLoad(ctx context.Context, key K, loader LoaderFunc[K, V]) (V, error) {
+// getOrLoad retrieves a value from the shard or loads it using the provided
+// loader function. ns selects the namespace the key belongs to (see
+// Cache.Namespace); 0 is the root namespace.
+//
+// Concurrent misses on the same key are coalesced through s.loaders: the
+// first goroutine to arrive runs the loader and populates the arena, while
+// later callers for the same key wait on the shared in-flight call instead
+// of invoking the loader themselves. The hashed key already computed here is
+// reused as the singleflight key. cache.WithLoaderCoalescing(false) bypasses
+// s.loaders entirely, running the loader directly on every miss; see
+// cache.WithLoaderTimeout for bounding how long one invocation may run
+// before loaderGroup hands leadership to a waiter.
+func (s *shard[K, V]) getOrLoad(ctx context.Context, ns uint32, key K, loader LoaderFunc[K, V]) (V, error) {
     // Attempt to get the value from the shard
-    if val, ok := s.get(key); ok {
+    if val, ok := s.get(ns, key); ok {
         return val, nil
     }
-    // Load the value using the loader function
-    return loader(ctx, key)
+    h := s.hashNS(ns, key)
+
+    // A live tombstone (see cache.WithNegativeTTL) short-circuits straight to
+    // the cached negative result, bypassing both the fallback and the loader.
+    if err, live := s.tombstone(h, key); live {
+        s.negativeHits.Add(1)
+        var zero V
+        return zero, err
+    }
+
+    // Consult the L2 fallback (see cache.WithFallback) before paying for the
+    // caller's loader: a Redis/Pebble/… tier is typically much cheaper than
+    // recomputing the value from scratch. The fallback is shared across
+    // namespaces and keyed by the raw key alone.
+    if s.fallback != nil {
+        if val, ok, err := s.fallback.Get(ctx, key); err == nil && ok {
+            s.put(ctx, ns, key, val, s.weightFn(val))
+            return val, nil
+        }
+    }
+
+    ran := false // set when this caller's own run executes, not a shared one
+    run := func(ctx context.Context, key K) (V, error) {
+        ran = true
+        if s.loaderTimeout > 0 {
+            var cancel context.CancelFunc
+            ctx, cancel = context.WithTimeout(ctx, s.loaderTimeout)
+            defer cancel()
+        }
+        s.metrics.addLoaderInflight(s.idx, 1)
+        defer s.metrics.addLoaderInflight(s.idx, -1)
+
+        v, loadErr := loader(ctx, key)
+        if loadErr == nil {
+            s.put(ctx, ns, key, v, s.weightFn(v))
+            return v, nil
+        }
+        if s.negativeTTL > 0 && s.isNegativeFn(loadErr) {
+            s.putTombstone(h, key, loadErr)
+        }
+        return v, loadErr
+    }
+
+    s.loads.Add(1)
+
+    if !s.loaderCoalescing {
+        return run(ctx, key)
+    }
+
+    val, err, shared := s.loaders.load(ctx, h, key, run)
+    if shared && !ran {
+        s.loadDuplicates.Add(1)
+        s.metrics.incLoaderCoalesced(s.idx)
+    }
+    return val, err
+}
+
+// tombstone returns the cached negative result for key, if a live tombstone
+// exists. An expired tombstone is reported as absent so the caller falls
+// through to a fresh load; it is lazily overwritten on the next putTombstone
+// or eviction sweep rather than actively purged here.
+func (s *shard[K, V]) tombstone(h uint64, key K) (err error, live bool) {
+    s.mu.RLock()
+    ent, found := s.index[h]
+    s.mu.RUnlock()
+    if !found || ent.key != key || ent.state&0b11 != stateTombstone {
+        return nil, false
+    }
+    if time.Now().UnixNano() > ent.expireAt {
+        return nil, false
+    }
+    return ent.tombErr, true
+}
+
+// tombstoneWeight approximates the metadata overhead of one tombstone (the
+// index map slot plus the entry struct) for cache.WithNegativeCapacityFraction.
+// Tombstones never allocate arena memory, so this is a fixed nominal cost
+// rather than anything derived from weightFn.
+const tombstoneWeight = 64
+
+// putTombstone records a compact negative-result marker for key: no arena
+// allocation is made, only an entry carrying the error and its own expiry.
+// A real value arriving later (via put) overwrites the map slot normally.
+// If live tombstones already occupy s.negativeCapBytes (see
+// cache.WithNegativeCapacityFraction), the soonest-to-expire ones are
+// evicted first to make room for this one.
+func (s *shard[K, V]) putTombstone(h uint64, key K, err error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    existing, hadEntry := s.index[h]
+    isNewTombstone := !hadEntry || existing.state&0b11 != stateTombstone
+    if isNewTombstone {
+        for s.negativeWeight.Load()+tombstoneWeight > s.negativeCapBytes {
+            if !s.evictOldestTombstoneLocked() {
+                break
+            }
+        }
+    }
+    if hadEntry && existing.state&0b11 != stateTombstone {
+        // existing is a live Hot/Cold node shared by pointer with the
+        // CLOCK-Pro ring; overwriting s.index[h] below would otherwise leave
+        // it linked in c.clock, still byte-accounted and eligible to fire
+        // ejectCb, with no way back to it from the index.
+        s.clock.Remove(unsafe.Pointer(existing))
+        s.reportClockSizes()
+    }
+
+    s.index[h] = &entry[K, V]{
+        h:        h,
+        key:      key,
+        state:    stateTombstone,
+        tombErr:  err,
+        expireAt: time.Now().Add(s.negativeTTL).UnixNano(),
+    }
+    if isNewTombstone {
+        s.negativeWeight.Add(tombstoneWeight)
+    }
+}
+
+// evictOldestTombstoneLocked removes the tombstone with the nearest
+// expireAt – FIFO by expiry, since a constant negativeTTL makes insertion
+// order and expiry order coincide. Callers must hold s.mu. Reports whether
+// a tombstone was found to evict.
+func (s *shard[K, V]) evictOldestTombstoneLocked() bool {
+    var oldestHash uint64
+    var oldestExpire int64
+    found := false
+    for h, ent := range s.index {
+        if ent.state&0b11 != stateTombstone {
+            continue
+        }
+        if !found || ent.expireAt < oldestExpire {
+            oldestHash, oldestExpire = h, ent.expireAt
+            found = true
+        }
+    }
+    if found {
+        delete(s.index, oldestHash)
+        s.negativeWeight.Add(-tombstoneWeight)
+    }
+    return found
+}
+
+// loadStats returns the singleflight counters – useful for Prometheus.
+func (s *shard[K, V]) loadStats() (loads, duplicates uint64) {
+    return s.loads.Load(), s.loadDuplicates.Load()
+}
+
+// sweepTombstones deletes every tombstone (see WithNegativeTTL) whose TTL has
+// already elapsed. Used by the Sweeper middleware; safe to call concurrently
+// with normal traffic.
+func (s *shard[K, V]) sweepTombstones() (removed int) {
+    now := time.Now().UnixNano()
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for h, ent := range s.index {
+        if ent.state&0b11 == stateTombstone && now > ent.expireAt {
+            delete(s.index, h)
+            s.negativeWeight.Add(-tombstoneWeight)
+            removed++
+        }
+    }
+    return removed
 }
 
 // sizeBytes returns the total size in bytes of the shard.
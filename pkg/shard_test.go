@@ -0,0 +1,94 @@
+package cache
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/Voskan/arena-cache/internal/clockpro"
+)
+
+// newTestShard builds a shard with sane defaults for white-box tests,
+// leaving most of newShard's knobs at their zero value (no fallback, no
+// loader timeout, no tracer); loader coalescing is on.
+func newTestShard(t *testing.T, capBytes int64, negativeTTL time.Duration, ejectCb func(string, int, clockpro.EvictionReason)) *shard[string, int] {
+    t.Helper()
+    weightFn := func(v int) int { return 8 }
+    return newShard[string, int](0, capBytes, time.Hour, weightFn, ejectCb,
+        nil, false,
+        negativeTTL, isNegative, 1.0,
+        noopMetrics{},
+        true, 0,
+        0.5, capBytes,
+        nil, 4)
+}
+
+// TestPutTombstoneRemovesLiveEntryFromClock covers the chunk0-3 fix: turning
+// a live Hot/Cold key into a tombstone must unlink its old *entry from
+// clockpro.Clock, not just overwrite s.index[h]. Otherwise the old node
+// stays in the ring, still byte-accounted and still eligible to fire
+// ejectCb, even though Get/Put can never reach it again.
+func TestPutTombstoneRemovesLiveEntryFromClock(t *testing.T) {
+    var evicted []string
+    s := newTestShard(t, 1<<20, time.Minute, func(k string, _ int, _ clockpro.EvictionReason) {
+        evicted = append(evicted, k)
+    })
+
+    ctx := context.Background()
+    s.put(ctx, 0, "k", 42, 8)
+    if _, ok := s.get(0, "k"); !ok {
+        t.Fatalf("expected k to be present after put")
+    }
+
+    hotBefore, coldBefore, _, _ := s.clock.Sizes()
+    if hotBefore+coldBefore == 0 {
+        t.Fatalf("expected the live entry to be tracked by the clock before tombstoning")
+    }
+
+    h := s.hashNS(0, "k")
+    s.putTombstone(h, "k", ErrNotFound)
+
+    hotAfter, coldAfter, _, _ := s.clock.Sizes()
+    if hotAfter != 0 || coldAfter != 0 {
+        t.Fatalf("expected the old live entry to be unlinked from the clock, got hot=%d cold=%d", hotAfter, coldAfter)
+    }
+
+    // Force the kind of capacity eviction that would have fired ejectCb for
+    // the zombie node, were it still reachable from the ring.
+    for i := 0; i < 1<<18; i++ { // 2 MiB of 8-byte puts against a 1 MiB shard
+        s.put(ctx, 0, fmt.Sprintf("filler-%d", i), i, 8)
+    }
+    for _, k := range evicted {
+        if k == "k" {
+            t.Fatalf("tombstoned key %q should never be reported to ejectCb again", k)
+        }
+    }
+}
+
+// TestRotateIfIdleConcurrentWithPut covers the chunk0-4 fix: the Sweeper
+// middleware's "rotate an idle shard" path must take s.mu for the whole
+// check-and-rotate, the same as every other rotate() call site (put,
+// reconfigure). Run with -race: a len()==0 check followed by an unlocked
+// rotate() races with a concurrent Put mutating genRing/clock.
+func TestRotateIfIdleConcurrentWithPut(t *testing.T) {
+    s := newTestShard(t, 1<<16, 0, nil)
+    ctx := context.Background()
+
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        for i := 0; i < 2000; i++ {
+            s.put(ctx, 0, fmt.Sprintf("k-%d", i%8), i, 8)
+        }
+    }()
+    go func() {
+        defer wg.Done()
+        for i := 0; i < 2000; i++ {
+            s.rotateIfIdle()
+        }
+    }()
+    wg.Wait()
+}
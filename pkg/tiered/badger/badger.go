@@ -0,0 +1,131 @@
+// Package badger adapts a BadgerDB instance to the tiered.Store interface
+// so it can back a tiered.Tiered[K,V] as an embedded, LSM-backed L2 tier –
+// the same role it plays in examples/disk_eject, but with Iterate and
+// ApproxSize so the compactor can trim it.
+//
+// © 2025 arena-cache authors. MIT License.
+package badger
+
+import (
+    "context"
+
+    bdg "github.com/dgraph-io/badger/v4"
+
+    "github.com/Voskan/arena-cache/pkg/tiered"
+)
+
+// Store is a tiered.Store backed by an already-open *badger.DB.
+type Store[K comparable, V any] struct {
+    db    *bdg.DB
+    keys  tiered.KeyCodec[K]
+    vals  tiered.ValueCodec[V]
+}
+
+// New constructs a Badger-backed tiered.Store.
+func New[K comparable, V any](db *bdg.DB, keys tiered.KeyCodec[K], vals tiered.ValueCodec[V]) *Store[K, V] {
+    return &Store[K, V]{db: db, keys: keys, vals: vals}
+}
+
+// Get implements tiered.Store.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return zero, false, err
+    }
+
+    var raw []byte
+    err = s.db.View(func(txn *bdg.Txn) error {
+        item, err := txn.Get(rawKey)
+        if err != nil {
+            return err
+        }
+        return item.Value(func(b []byte) error {
+            raw = append([]byte(nil), b...)
+            return nil
+        })
+    })
+    if err == bdg.ErrKeyNotFound {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+
+    v, err := s.vals.DecodeValue(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements tiered.Store.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    rawVal, err := s.vals.EncodeValue(val)
+    if err != nil {
+        return err
+    }
+    return s.db.Update(func(txn *bdg.Txn) error {
+        return txn.Set(rawKey, rawVal)
+    })
+}
+
+// Delete implements tiered.Store.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    return s.db.Update(func(txn *bdg.Txn) error {
+        return txn.Delete(rawKey)
+    })
+}
+
+// Iterate implements tiered.Store.
+func (s *Store[K, V]) Iterate(ctx context.Context, fn func(key K, val V) bool) error {
+    return s.db.View(func(txn *bdg.Txn) error {
+        opts := bdg.DefaultIteratorOptions
+        it := txn.NewIterator(opts)
+        defer it.Close()
+        for it.Rewind(); it.Valid(); it.Next() {
+            item := it.Item()
+            key, err := s.keys.DecodeKey(item.KeyCopy(nil))
+            if err != nil {
+                return err
+            }
+            var raw []byte
+            if err := item.Value(func(b []byte) error {
+                raw = append([]byte(nil), b...)
+                return nil
+            }); err != nil {
+                return err
+            }
+            val, err := s.vals.DecodeValue(raw)
+            if err != nil {
+                return err
+            }
+            if !fn(key, val) {
+                break
+            }
+        }
+        return nil
+    })
+}
+
+// ApproxSize implements tiered.Store, summing Badger's LSM-tree and
+// value-log footprint.
+func (s *Store[K, V]) ApproxSize(ctx context.Context) (int64, error) {
+    lsm, vlog := s.db.Size()
+    return lsm + vlog, nil
+}
+
+// Close implements tiered.Store.
+func (s *Store[K, V]) Close() error {
+    return s.db.Close()
+}
+
+var _ tiered.Store[string, []byte] = (*Store[string, []byte])(nil)
@@ -0,0 +1,138 @@
+// Package bolt adapts a BoltDB (go.etcd.io/bbolt) database to the
+// tiered.Store interface so it can back a tiered.Tiered[K,V] as a simple,
+// single-file, embedded L2 tier – a lighter-weight alternative to the
+// LSM-backed Badger/Pebble adapters for workloads that don't need their
+// write throughput.
+//
+// © 2025 arena-cache authors. MIT License.
+package bolt
+
+import (
+    "context"
+    "os"
+
+    bolt "go.etcd.io/bbolt"
+
+    "github.com/Voskan/arena-cache/pkg/tiered"
+)
+
+// defaultBucket is the single bucket Store keeps all entries in. A future
+// version could expose the bucket name as a constructor argument if callers
+// need to share one bbolt file across several Tiered caches.
+var defaultBucket = []byte("arena_cache_tiered")
+
+// Store is a tiered.Store backed by an already-open *bolt.DB.
+type Store[K comparable, V any] struct {
+    db     *bolt.DB
+    bucket []byte
+    keys   tiered.KeyCodec[K]
+    vals   tiered.ValueCodec[V]
+}
+
+// New constructs a BoltDB-backed tiered.Store, creating defaultBucket if it
+// does not already exist.
+func New[K comparable, V any](db *bolt.DB, keys tiered.KeyCodec[K], vals tiered.ValueCodec[V]) (*Store[K, V], error) {
+    err := db.Update(func(tx *bolt.Tx) error {
+        _, err := tx.CreateBucketIfNotExists(defaultBucket)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &Store[K, V]{db: db, bucket: defaultBucket, keys: keys, vals: vals}, nil
+}
+
+// Get implements tiered.Store.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return zero, false, err
+    }
+
+    var raw []byte
+    err = s.db.View(func(tx *bolt.Tx) error {
+        if v := tx.Bucket(s.bucket).Get(rawKey); v != nil {
+            raw = append([]byte(nil), v...)
+        }
+        return nil
+    })
+    if err != nil {
+        return zero, false, err
+    }
+    if raw == nil {
+        return zero, false, nil
+    }
+
+    v, err := s.vals.DecodeValue(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements tiered.Store.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    rawVal, err := s.vals.EncodeValue(val)
+    if err != nil {
+        return err
+    }
+    return s.db.Update(func(tx *bolt.Tx) error {
+        return tx.Bucket(s.bucket).Put(rawKey, rawVal)
+    })
+}
+
+// Delete implements tiered.Store.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    return s.db.Update(func(tx *bolt.Tx) error {
+        return tx.Bucket(s.bucket).Delete(rawKey)
+    })
+}
+
+// Iterate implements tiered.Store.
+func (s *Store[K, V]) Iterate(ctx context.Context, fn func(key K, val V) bool) error {
+    return s.db.View(func(tx *bolt.Tx) error {
+        c := tx.Bucket(s.bucket).Cursor()
+        for rawKey, rawVal := c.First(); rawKey != nil; rawKey, rawVal = c.Next() {
+            key, err := s.keys.DecodeKey(rawKey)
+            if err != nil {
+                return err
+            }
+            val, err := s.vals.DecodeValue(rawVal)
+            if err != nil {
+                return err
+            }
+            if !fn(key, val) {
+                break
+            }
+        }
+        return nil
+    })
+}
+
+// ApproxSize implements tiered.Store, reporting the size of the underlying
+// bbolt file on disk.
+func (s *Store[K, V]) ApproxSize(ctx context.Context) (int64, error) {
+    fi, err := os.Stat(s.db.Path())
+    if err != nil {
+        return 0, err
+    }
+    return fi.Size(), nil
+}
+
+// Close implements tiered.Store. bbolt's *DB is typically owned (and
+// closed) by the application rather than the adapter, but we mirror the
+// other adapters' contract for symmetry.
+func (s *Store[K, V]) Close() error {
+    return s.db.Close()
+}
+
+var _ tiered.Store[string, []byte] = (*Store[string, []byte])(nil)
@@ -0,0 +1,67 @@
+package tiered
+
+// compactor.go runs a background goroutine that keeps the L2 store under
+// the budget configured via WithBudget, trimming whichever entries Iterate
+// happens to visit first once the store's footprint exceeds that ceiling.
+// Store implementations that want a more deliberate eviction order (LRU,
+// size-weighted…) are free to ignore Iterate's ordering contract and sort
+// internally; the compactor only assumes Iterate visits every live entry
+// exactly once per call.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "context"
+    "time"
+)
+
+// startCompactor launches the ticker goroutine and returns a stop func, in
+// the same shape cache.Sweeper's Middleware.Attach uses (see
+// pkg/middleware.go) so Tiered.Close can shut it down the same way Cache.Close
+// shuts down middleware-attached background work.
+func (t *Tiered[K, V]) startCompactor(interval time.Duration, batch int) func() {
+    done := make(chan struct{})
+    ticker := time.NewTicker(interval)
+    go func() {
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                t.compactOnce(batch)
+            case <-done:
+                return
+            }
+        }
+    }()
+    return func() { close(done) }
+}
+
+// compactOnce trims at most batch entries if the store is currently over
+// budget. It re-checks ApproxSize after every deletion batch of one so a
+// store whose size shrinks faster than expected doesn't get over-trimmed.
+func (t *Tiered[K, V]) compactOnce(batch int) {
+    ctx := context.Background()
+
+    size, err := t.store.ApproxSize(ctx)
+    if err != nil || size <= t.budgetBytes {
+        return
+    }
+
+    trimmed := 0
+    var toDelete []K
+    _ = t.store.Iterate(ctx, func(key K, _ V) bool {
+        toDelete = append(toDelete, key)
+        trimmed++
+        return trimmed < batch
+    })
+
+    for _, key := range toDelete {
+        if err := t.store.Delete(ctx, key); err != nil {
+            t.storeErrors.Add(1)
+            continue
+        }
+        if size, err = t.store.ApproxSize(ctx); err == nil && size <= t.budgetBytes {
+            break
+        }
+    }
+}
@@ -0,0 +1,158 @@
+// Package fschunk adapts a plain directory of files to the tiered.Store
+// interface: each entry is stored as one file, named by the hex encoding of
+// its key, holding the encoded value verbatim. It has no external
+// dependency and no background compaction of its own (see tiered.WithBudget
+// for that) – it exists for the common case where a dedicated KV engine is
+// overkill and the L2 tier is really just "spill large values to disk".
+//
+// © 2025 arena-cache authors. MIT License.
+package fschunk
+
+import (
+    "context"
+    "encoding/hex"
+    "errors"
+    "os"
+    "path/filepath"
+
+    "github.com/Voskan/arena-cache/pkg/tiered"
+)
+
+// Store is a tiered.Store backed by a directory of chunk files.
+type Store[K comparable, V any] struct {
+    dir  string
+    keys tiered.KeyCodec[K]
+    vals tiered.ValueCodec[V]
+}
+
+// New constructs a filesystem-backed tiered.Store rooted at dir, creating it
+// (and any missing parents) if necessary.
+func New[K comparable, V any](dir string, keys tiered.KeyCodec[K], vals tiered.ValueCodec[V]) (*Store[K, V], error) {
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return nil, err
+    }
+    return &Store[K, V]{dir: dir, keys: keys, vals: vals}, nil
+}
+
+func (s *Store[K, V]) path(rawKey []byte) string {
+    return filepath.Join(s.dir, hex.EncodeToString(rawKey))
+}
+
+// Get implements tiered.Store.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return zero, false, err
+    }
+
+    raw, err := os.ReadFile(s.path(rawKey))
+    if errors.Is(err, os.ErrNotExist) {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+
+    v, err := s.vals.DecodeValue(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements tiered.Store. The write goes through a temp file and
+// os.Rename so a crash mid-write never leaves a partially-written chunk
+// behind for Get to trip over.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    rawVal, err := s.vals.EncodeValue(val)
+    if err != nil {
+        return err
+    }
+
+    dst := s.path(rawKey)
+    tmp := dst + ".tmp"
+    if err := os.WriteFile(tmp, rawVal, 0o644); err != nil {
+        return err
+    }
+    return os.Rename(tmp, dst)
+}
+
+// Delete implements tiered.Store.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    err = os.Remove(s.path(rawKey))
+    if errors.Is(err, os.ErrNotExist) {
+        return nil
+    }
+    return err
+}
+
+// Iterate implements tiered.Store, walking the directory's entries in
+// whatever order the filesystem returns them.
+func (s *Store[K, V]) Iterate(ctx context.Context, fn func(key K, val V) bool) error {
+    entries, err := os.ReadDir(s.dir)
+    if err != nil {
+        return err
+    }
+
+    for _, e := range entries {
+        if e.IsDir() || filepath.Ext(e.Name()) == ".tmp" {
+            continue
+        }
+        rawKey, err := hex.DecodeString(e.Name())
+        if err != nil {
+            continue // not one of our chunk files
+        }
+        key, err := s.keys.DecodeKey(rawKey)
+        if err != nil {
+            return err
+        }
+        raw, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+        if err != nil {
+            return err
+        }
+        val, err := s.vals.DecodeValue(raw)
+        if err != nil {
+            return err
+        }
+        if !fn(key, val) {
+            break
+        }
+    }
+    return nil
+}
+
+// ApproxSize implements tiered.Store by summing the size of every chunk
+// file under dir.
+func (s *Store[K, V]) ApproxSize(ctx context.Context) (int64, error) {
+    entries, err := os.ReadDir(s.dir)
+    if err != nil {
+        return 0, err
+    }
+
+    var total int64
+    for _, e := range entries {
+        if e.IsDir() {
+            continue
+        }
+        info, err := e.Info()
+        if err != nil {
+            return 0, err
+        }
+        total += info.Size()
+    }
+    return total, nil
+}
+
+// Close implements tiered.Store. A plain directory needs no teardown.
+func (s *Store[K, V]) Close() error { return nil }
+
+var _ tiered.Store[string, []byte] = (*Store[string, []byte])(nil)
@@ -0,0 +1,49 @@
+package tiered
+
+// metrics.go mirrors Tiered.Stats onto Prometheus, the same way
+// namespaceMetrics mirrors Cache.NamespaceStats in pkg/metrics.go: a small
+// struct of ready-made collectors registered once in newTieredMetrics,
+// updated from the same call sites that maintain the atomic counters.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// tieredMetrics holds the Prometheus collectors backing WithMetrics.
+type tieredMetrics struct {
+    l1Hits       prometheus.Counter
+    l2Hits       prometheus.Counter
+    l2Promotions prometheus.Counter
+    l2Bytes      prometheus.Gauge
+}
+
+func newTieredMetrics(reg *prometheus.Registry) *tieredMetrics {
+    m := &tieredMetrics{
+        l1Hits: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Subsystem: "tiered",
+            Name:      "l1_hits_total",
+            Help:      "Number of Tiered.GetOrLoad calls served without consulting the L2 store.",
+        }),
+        l2Hits: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Subsystem: "tiered",
+            Name:      "l2_hits_total",
+            Help:      "Number of Tiered.GetOrLoad calls served from the L2 store.",
+        }),
+        l2Promotions: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "arena_cache",
+            Subsystem: "tiered",
+            Name:      "l2_promotions_total",
+            Help:      "Number of L2 hits promoted back into L1.",
+        }),
+        l2Bytes: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "arena_cache",
+            Subsystem: "tiered",
+            Name:      "l2_bytes",
+            Help:      "Last-observed Store.ApproxSize of the L2 tier.",
+        }),
+    }
+    reg.MustRegister(m.l1Hits, m.l2Hits, m.l2Promotions, m.l2Bytes)
+    return m
+}
@@ -0,0 +1,109 @@
+// Package pebble adapts a CockroachDB Pebble key-value store to the
+// tiered.Store interface so it can back a tiered.Tiered[K,V] as an on-disk
+// L2 tier.
+//
+// © 2025 arena-cache authors. MIT License.
+package pebble
+
+import (
+    "context"
+
+    "github.com/cockroachdb/pebble"
+
+    "github.com/Voskan/arena-cache/pkg/tiered"
+)
+
+// Store is a tiered.Store backed by an already-open *pebble.DB.
+type Store[K comparable, V any] struct {
+    db   *pebble.DB
+    keys tiered.KeyCodec[K]
+    vals tiered.ValueCodec[V]
+}
+
+// New constructs a Pebble-backed tiered.Store.
+func New[K comparable, V any](db *pebble.DB, keys tiered.KeyCodec[K], vals tiered.ValueCodec[V]) *Store[K, V] {
+    return &Store[K, V]{db: db, keys: keys, vals: vals}
+}
+
+// Get implements tiered.Store.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return zero, false, err
+    }
+
+    raw, closer, err := s.db.Get(rawKey)
+    if err == pebble.ErrNotFound {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+    defer closer.Close()
+
+    v, err := s.vals.DecodeValue(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements tiered.Store.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    rawVal, err := s.vals.EncodeValue(val)
+    if err != nil {
+        return err
+    }
+    return s.db.Set(rawKey, rawVal, pebble.Sync)
+}
+
+// Delete implements tiered.Store.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    return s.db.Delete(rawKey, pebble.Sync)
+}
+
+// Iterate implements tiered.Store.
+func (s *Store[K, V]) Iterate(ctx context.Context, fn func(key K, val V) bool) error {
+    it, err := s.db.NewIter(nil)
+    if err != nil {
+        return err
+    }
+    defer it.Close()
+
+    for valid := it.First(); valid; valid = it.Next() {
+        key, err := s.keys.DecodeKey(append([]byte(nil), it.Key()...))
+        if err != nil {
+            return err
+        }
+        val, err := s.vals.DecodeValue(append([]byte(nil), it.Value()...))
+        if err != nil {
+            return err
+        }
+        if !fn(key, val) {
+            break
+        }
+    }
+    return it.Error()
+}
+
+// ApproxSize implements tiered.Store, reporting Pebble's own disk-space
+// estimate.
+func (s *Store[K, V]) ApproxSize(ctx context.Context) (int64, error) {
+    return int64(s.db.Metrics().DiskSpaceUsage()), nil
+}
+
+// Close implements tiered.Store.
+func (s *Store[K, V]) Close() error {
+    return s.db.Close()
+}
+
+var _ tiered.Store[string, []byte] = (*Store[string, []byte])(nil)
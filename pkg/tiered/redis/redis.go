@@ -0,0 +1,130 @@
+// Package redis adapts a Redis client to the tiered.Store interface so it
+// can back a tiered.Tiered[K,V] as a shared, networked L2 tier.
+//
+// © 2025 arena-cache authors. MIT License.
+package redis
+
+import (
+    "context"
+
+    goredis "github.com/redis/go-redis/v9"
+
+    "github.com/Voskan/arena-cache/pkg/tiered"
+)
+
+// Store is a tiered.Store backed by a Redis client. prefix namespaces the
+// keyspace when a Redis instance is shared by several Tiered caches; Iterate
+// and ApproxSize only ever consider keys under prefix.
+type Store[K comparable, V any] struct {
+    client *goredis.Client
+    keys   tiered.KeyCodec[K]
+    vals   tiered.ValueCodec[V]
+    prefix string
+}
+
+// New constructs a Redis-backed tiered.Store.
+func New[K comparable, V any](client *goredis.Client, keys tiered.KeyCodec[K], vals tiered.ValueCodec[V], prefix string) *Store[K, V] {
+    return &Store[K, V]{client: client, keys: keys, vals: vals, prefix: prefix}
+}
+
+func (s *Store[K, V]) fullKey(rawKey []byte) string {
+    return s.prefix + string(rawKey)
+}
+
+// Get implements tiered.Store.
+func (s *Store[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+    var zero V
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return zero, false, err
+    }
+
+    raw, err := s.client.Get(ctx, s.fullKey(rawKey)).Bytes()
+    if err == goredis.Nil {
+        return zero, false, nil
+    }
+    if err != nil {
+        return zero, false, err
+    }
+
+    v, err := s.vals.DecodeValue(raw)
+    if err != nil {
+        return zero, false, err
+    }
+    return v, true, nil
+}
+
+// Set implements tiered.Store.
+func (s *Store[K, V]) Set(ctx context.Context, key K, val V) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    rawVal, err := s.vals.EncodeValue(val)
+    if err != nil {
+        return err
+    }
+    return s.client.Set(ctx, s.fullKey(rawKey), rawVal, 0).Err()
+}
+
+// Delete implements tiered.Store.
+func (s *Store[K, V]) Delete(ctx context.Context, key K) error {
+    rawKey, err := s.keys.EncodeKey(key)
+    if err != nil {
+        return err
+    }
+    return s.client.Del(ctx, s.fullKey(rawKey)).Err()
+}
+
+// Iterate implements tiered.Store, walking prefix+"*" with SCAN rather than
+// KEYS so it doesn't block the server on a large keyspace.
+func (s *Store[K, V]) Iterate(ctx context.Context, fn func(key K, val V) bool) error {
+    iter := s.client.Scan(ctx, 0, s.prefix+"*", 100).Iterator()
+    for iter.Next(ctx) {
+        fullKey := iter.Val()
+        rawKey := []byte(fullKey[len(s.prefix):])
+        key, err := s.keys.DecodeKey(rawKey)
+        if err != nil {
+            return err
+        }
+
+        raw, err := s.client.Get(ctx, fullKey).Bytes()
+        if err == goredis.Nil {
+            continue // evicted/expired between SCAN and GET
+        }
+        if err != nil {
+            return err
+        }
+        val, err := s.vals.DecodeValue(raw)
+        if err != nil {
+            return err
+        }
+        if !fn(key, val) {
+            break
+        }
+    }
+    return iter.Err()
+}
+
+// ApproxSize implements tiered.Store by summing MEMORY USAGE across every
+// key under prefix. This is O(n) in the keyspace size – fine for a
+// periodic compactor tick, not for the hot path.
+func (s *Store[K, V]) ApproxSize(ctx context.Context) (int64, error) {
+    var total int64
+    iter := s.client.Scan(ctx, 0, s.prefix+"*", 100).Iterator()
+    for iter.Next(ctx) {
+        n, err := s.client.MemoryUsage(ctx, iter.Val()).Result()
+        if err != nil {
+            continue // key may have expired since SCAN returned it
+        }
+        total += n
+    }
+    return total, iter.Err()
+}
+
+// Close implements tiered.Store.
+func (s *Store[K, V]) Close() error {
+    return s.client.Close()
+}
+
+var _ tiered.Store[string, []byte] = (*Store[string, []byte])(nil)
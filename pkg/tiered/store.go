@@ -0,0 +1,63 @@
+// Package tiered promotes the pattern demonstrated by examples/disk_eject –
+// arena-cache as an L1 in front of an on-disk or networked L2 – into a
+// first-class, reusable subsystem. Rolling your own gets the races wrong in
+// predictable ways: double-writes when both EjectCallback and the loader
+// persist the same key, missing tombstones for values the loader decided not
+// to cache, and no accounting of how large the L2 has grown. Tiered[K,V]
+// (see tiered.go) centralises all three.
+//
+// Store is deliberately smaller than provider.Provider (see
+// pkg/provider/provider.go): it adds Iterate and ApproxSize, which a
+// WithFallback L2 never needed but a Tiered cache's background compactor
+// does, and it drops provider.Provider's per-Set ttl/weight parameters –
+// Tiered treats the L2 as a flat store and relies on its own budget/
+// compaction knobs instead of a per-key TTL.
+//
+// © 2025 arena-cache authors. MIT License.
+package tiered
+
+import "context"
+
+// Store is an L2 backend that can sit behind a Tiered[K,V]. Implementations
+// must be safe for concurrent use.
+type Store[K comparable, V any] interface {
+    // Get fetches a value for key. The bool reports whether it was present;
+    // a miss is not an error.
+    Get(ctx context.Context, key K) (V, bool, error)
+
+    // Set stores a value for key, overwriting any existing entry.
+    Set(ctx context.Context, key K, val V) error
+
+    // Delete removes key from the store, if present.
+    Delete(ctx context.Context, key K) error
+
+    // Iterate calls fn once for every entry currently in the store, in
+    // store-defined order, until fn returns false or every entry has been
+    // visited. It is used by the background compactor (see
+    // WithBudget) and must be safe to call concurrently with Get/Set.
+    Iterate(ctx context.Context, fn func(key K, val V) bool) error
+
+    // ApproxSize reports the store's current footprint in bytes. It need
+    // not be exact – the compactor only needs it to decide whether the
+    // configured budget has been exceeded.
+    ApproxSize(ctx context.Context) (int64, error)
+
+    // Close releases resources held by the store (connections, files…).
+    Close() error
+}
+
+// KeyCodec converts a cache key to/from the byte representation an adapter
+// persists. Adapters that can use K directly (e.g. a string key written
+// as-is) don't need one; it exists for non-string generics.
+type KeyCodec[K comparable] interface {
+    EncodeKey(K) ([]byte, error)
+    DecodeKey([]byte) (K, error)
+}
+
+// ValueCodec converts a cache value to/from the byte representation an
+// adapter persists. arena-cache ships no default codec because the right
+// choice (JSON, gob, protobuf…) is application specific.
+type ValueCodec[V any] interface {
+    EncodeValue(V) ([]byte, error)
+    DecodeValue([]byte) (V, error)
+}
@@ -0,0 +1,243 @@
+package tiered
+
+// tiered.go wires a Store (store.go) into a Cache[K,V]: evictions from L1
+// are written to the store via cache.WithEjectCallback, and GetOrLoad
+// consults the store before running the caller's LoaderFunc – promoting a
+// hit back into L1 as a side effect of the normal Put the cache already
+// performs on a successful load. Both paths are installed once, at
+// construction, so callers get the disk_eject example's behaviour without
+// re-deriving it (and its races) themselves.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "context"
+    "sync/atomic"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+
+    cache "github.com/Voskan/arena-cache/pkg"
+)
+
+// Tiered wraps a Cache[K,V] with a Store L2. Use GetOrLoad in place of the
+// underlying Cache's GetOrLoad; Get, Put and Delete can be used directly on
+// Tiered.Cache() when L2 participation isn't needed for that call.
+type Tiered[K comparable, V any] struct {
+    c     *cache.Cache[K, V]
+    store Store[K, V]
+
+    budgetBytes int64
+    storeErrors atomic.Uint64
+
+    // l1Hits/l2Hits/l2Promotions/l2Bytes back Stats(); stats mirrors them
+    // onto reg when built with WithMetrics. l2Hits and l2Promotions are
+    // counted together today (a store hit is always promoted into L1 by the
+    // cache's own Put-on-load), but are kept as separate counters so a
+    // future store that can report a hit without promoting – e.g. one that
+    // intentionally skips promotion for very large values – has somewhere
+    // to report the distinction.
+    l1Hits       atomic.Uint64
+    l2Hits       atomic.Uint64
+    l2Promotions atomic.Uint64
+
+    stats *tieredMetrics // nil when built without WithMetrics
+
+    stopCompact func()
+}
+
+// tieredConfig bundles the knobs Option mutates before New builds the
+// underlying Cache.
+type tieredConfig[K comparable, V any] struct {
+    cacheOpts []cache.Option[K, V]
+    registry  *prometheus.Registry
+
+    budgetBytes     int64
+    compactInterval time.Duration
+    compactBatch    int
+}
+
+func defaultTieredConfig[K comparable, V any]() *tieredConfig[K, V] {
+    return &tieredConfig[K, V]{
+        compactInterval: time.Minute,
+        compactBatch:    1024,
+    }
+}
+
+// Option configures a Tiered[K,V] at construction time.
+type Option[K comparable, V any] func(*tieredConfig[K, V])
+
+// WithCacheOptions passes opts through to the underlying cache.New call,
+// letting callers set WithWeightFn, WithNegativeTTL, Use(...) and friends on
+// the L1 tier. Tiered installs its own EjectCallback internally, so a
+// WithEjectCallback passed here would be silently overridden – pass an
+// eject-observing Middleware instead if both are needed.
+func WithCacheOptions[K comparable, V any](opts ...cache.Option[K, V]) Option[K, V] {
+    return func(c *tieredConfig[K, V]) {
+        c.cacheOpts = append(c.cacheOpts, opts...)
+    }
+}
+
+// WithMetrics registers l1_hits/l2_hits/l2_promotions/l2_bytes on reg,
+// labelled the same way the rest of arena-cache's Prometheus surface is:
+// see metricsSink in pkg/metrics.go for the shard-level equivalent. Passing
+// nil disables metrics (default).
+func WithMetrics[K comparable, V any](reg *prometheus.Registry) Option[K, V] {
+    return func(c *tieredConfig[K, V]) {
+        c.registry = reg
+    }
+}
+
+// WithBudget caps the store's on-disk/in-memory footprint, as reported by
+// Store.ApproxSize, to roughly budgetBytes. Once exceeded, the background
+// compactor (see WithCompactInterval) trims entries until back under
+// budget. Zero (the default) disables compaction – the store grows
+// unbounded.
+func WithBudget[K comparable, V any](budgetBytes int64) Option[K, V] {
+    return func(c *tieredConfig[K, V]) {
+        c.budgetBytes = budgetBytes
+    }
+}
+
+// WithCompactInterval overrides how often the background compactor checks
+// Store.ApproxSize against the WithBudget ceiling. Default one minute; has
+// no effect without WithBudget.
+func WithCompactInterval[K comparable, V any](d time.Duration) Option[K, V] {
+    return func(c *tieredConfig[K, V]) {
+        if d > 0 {
+            c.compactInterval = d
+        }
+    }
+}
+
+// WithCompactBatch caps how many entries a single compaction pass deletes
+// before re-checking ApproxSize, bounding how long one tick can block the
+// compactor goroutine. Default 1024.
+func WithCompactBatch[K comparable, V any](n int) Option[K, V] {
+    return func(c *tieredConfig[K, V]) {
+        if n > 0 {
+            c.compactBatch = n
+        }
+    }
+}
+
+// New builds a Tiered[K,V]: a Cache[K,V] of the given capacity/ttl/shards
+// backed by store as its L2. store is consulted on every GetOrLoad miss,
+// before the caller's loader runs, and every capacity-triggered L1 eviction
+// is written back to it.
+func New[K comparable, V any](capBytes int64, ttl time.Duration, shards uint8, store Store[K, V], opts ...Option[K, V]) (*Tiered[K, V], error) {
+    cfg := defaultTieredConfig[K, V]()
+    for _, opt := range opts {
+        opt(cfg)
+    }
+
+    t := &Tiered[K, V]{
+        store:       store,
+        budgetBytes: cfg.budgetBytes,
+    }
+    if cfg.registry != nil {
+        t.stats = newTieredMetrics(cfg.registry)
+    }
+
+    eject := func(key K, val V, _ cache.EjectReason) {
+        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancel()
+        if err := store.Set(ctx, key, val); err != nil {
+            t.storeErrors.Add(1)
+        }
+    }
+    cacheOpts := append(append([]cache.Option[K, V]{}, cfg.cacheOpts...), cache.WithEjectCallback[K, V](eject))
+
+    c, err := cache.New[K, V](capBytes, ttl, shards, cacheOpts...)
+    if err != nil {
+        return nil, err
+    }
+    t.c = c
+
+    if cfg.budgetBytes > 0 {
+        t.stopCompact = t.startCompactor(cfg.compactInterval, cfg.compactBatch)
+    }
+
+    return t, nil
+}
+
+// Cache exposes the underlying Cache[K,V] for Get/Put/Delete calls that
+// don't need L2 participation (Put already reaches L2 indirectly, via
+// EjectCallback, once the value is evicted from L1).
+func (t *Tiered[K, V]) Cache() *cache.Cache[K, V] {
+    return t.c
+}
+
+// GetOrLoad looks up key in L1, then – on miss – in the L2 store, and only
+// then falls back to loader. A value found in the store is returned as-is
+// and, because Cache.GetOrLoad always Puts a successful result, promoted
+// back into L1 for subsequent lookups.
+//
+// reachedL2 (and therefore whether this call counts as an L1 hit or a
+// store/loader path) is approximate under singleflight dedup: a waiter that
+// shares another goroutine's in-flight result never runs the wrapped loader
+// itself and so is counted as an L1 hit even though the shared result came
+// from L2 or the loader. The same approximation already applies to
+// Cache.Namespace's hit/miss counters (see cache.go).
+func (t *Tiered[K, V]) GetOrLoad(ctx context.Context, key K, loader cache.LoaderFunc[K, V]) (V, error) {
+    reachedL2 := false
+    wrapped := func(ctx context.Context, key K) (V, error) {
+        reachedL2 = true
+        val, ok, err := t.store.Get(ctx, key)
+        if err != nil {
+            t.storeErrors.Add(1)
+        } else if ok {
+            t.l2Hits.Add(1)
+            t.l2Promotions.Add(1)
+            if t.stats != nil {
+                t.stats.l2Hits.Inc()
+                t.stats.l2Promotions.Inc()
+            }
+            return val, nil
+        }
+        return loader(ctx, key)
+    }
+
+    val, err := t.c.GetOrLoad(ctx, key, wrapped)
+    if !reachedL2 {
+        t.l1Hits.Add(1)
+        if t.stats != nil {
+            t.stats.l1Hits.Inc()
+        }
+    }
+    return val, err
+}
+
+// Stats reports the combined L1/L2 counters tracked since construction.
+type Stats struct {
+    L1Hits       uint64
+    L2Hits       uint64
+    L2Promotions uint64
+    L2Bytes      int64
+}
+
+// Stats returns the current counters. L2Bytes calls Store.ApproxSize, so it
+// carries whatever cost that has on the chosen backend.
+func (t *Tiered[K, V]) Stats(ctx context.Context) (Stats, error) {
+    l2Bytes, err := t.store.ApproxSize(ctx)
+    s := Stats{
+        L1Hits:       t.l1Hits.Load(),
+        L2Hits:       t.l2Hits.Load(),
+        L2Promotions: t.l2Promotions.Load(),
+        L2Bytes:      l2Bytes,
+    }
+    if t.stats != nil {
+        t.stats.l2Bytes.Set(float64(l2Bytes))
+    }
+    return s, err
+}
+
+// Close stops the background compactor, if running, and closes both the
+// underlying Cache and the Store.
+func (t *Tiered[K, V]) Close() {
+    if t.stopCompact != nil {
+        t.stopCompact()
+    }
+    t.c.Close()
+    t.store.Close()
+}
@@ -2,66 +2,73 @@
 
 package main
 
-// dataset_gen.go is a tiny helper utility to generate deterministic key
-// datasets for standalone benchmarking of arena-cache (outside `go test`).
-// It emits newline-separated uint64 numbers which can later be passed to
-// service load-testers or external benchmarking suites.
+// dataset_gen.go is a workload generator for standalone benchmarking of
+// arena-cache (outside `go test`): it can emit a plain key stream (the
+// original behaviour), a full read/write CSV workload with configurable
+// distributions and inter-arrival timing, or replay a real trace file
+// through the same CSV shape. See distributions.go for the key-distribution
+// implementations and replay.go for the trace-format parsers.
 //
 // Usage:
-//   go run bench/dataset_gen.go -n 1000000 -dist=zipf -seed=42 -out keys.txt
+//   go run ./tools/dataset_gen -n 1000000 -dist=zipf -seed=42 -out keys.txt
+//   go run ./tools/dataset_gen -mode=workload -dist=scrambled-zipf -n 100000 -read-ratio 0.95 -poisson-rate 5000 -out workload.csv
+//   go run ./tools/dataset_gen -replay twitter_cluster52.csv -format=twitter -out workload.csv
 //
 // Flags:
-//   -n       number of keys to generate (default 1e6)
-//   -dist    distribution: "uniform" or "zipf" (default uniform)
-//   -zipfs   Zipf s parameter (>1)  (default 1.2)
-//   -zipfv   Zipf v parameter (>1)  (default 1.0)
-//   -seed    RNG seed (default current time)
-//   -out     output file (default stdout)
-//
-// The program is *embarassingly simple* but placed under version control so
-// that any contributor can regenerate the exact dataset used in performance
-// regressions hunting.
+//   -mode        "keys" (default, legacy newline-separated uint64 stream) or
+//                "workload" ([t_ns,]op,key,size CSV)
+//   -n           number of keys/ops to generate (default 1e6)
+//   -dist        distribution: uniform, zipf, scrambled-zipf, latest, hotspot
+//                (default uniform)
+//   -zipfs       Zipf s parameter (>1)  (default 1.2)
+//   -zipfv       Zipf v parameter (>=1)  (default 1.0)
+//   -hotspot-frac    fraction of the keyspace considered "hot" (default 0.1)
+//   -hotspot-op-frac fraction of ops that target the hot set (default 0.9)
+//   -read-ratio  fraction of workload ops that are reads (default 0.9)
+//   -min-size/-max-size  value size range, inclusive, for workload mode (bytes)
+//   -poisson-rate    mean ops/sec; >0 adds a Poisson-arrival t_ns column to
+//                    workload mode (default 0, no timing column)
+//   -replay      path to a real trace file; when set, -dist/-n/-read-ratio/
+//                -poisson-rate are ignored and the trace drives the output
+//   -format      trace format for -replay: arc, twitter, or wiki
+//   -seed        RNG seed (default current time)
+//   -out         output file (default stdout)
 //
 // © 2025 arena-cache authors. MIT License.
 
 import (
-	"bufio"
-	"flag"
-	"fmt"
-	"math/rand"
-	"os"
-	"time"
+    "bufio"
+    "flag"
+    "fmt"
+    "math/rand"
+    "os"
+    "time"
 )
 
 func main() {
     var (
-        n       = flag.Int("n", 1_000_000, "number of keys to generate")
-        dist    = flag.String("dist", "uniform", "distribution: uniform or zipf")
+        mode    = flag.String("mode", "keys", "keys, or workload")
+        n       = flag.Int("n", 1_000_000, "number of keys/ops to generate")
+        dist    = flag.String("dist", "uniform", "uniform, zipf, scrambled-zipf, latest, or hotspot")
         zipfS   = flag.Float64("zipfs", 1.2, "zipf s parameter (>1)")
         zipfV   = flag.Float64("zipfv", 1.0, "zipf v parameter (>1)")
+
+        hotspotFrac   = flag.Float64("hotspot-frac", 0.1, "fraction of the keyspace considered hot")
+        hotspotOpFrac = flag.Float64("hotspot-op-frac", 0.9, "fraction of ops targeting the hot set")
+
+        readRatio = flag.Float64("read-ratio", 0.9, "fraction of workload ops that are reads")
+        minSize   = flag.Int("min-size", 64, "minimum value size in bytes (workload mode)")
+        maxSize   = flag.Int("max-size", 1024, "maximum value size in bytes (workload mode)")
+        poisson   = flag.Float64("poisson-rate", 0, "mean ops/sec; >0 adds a Poisson-arrival timing column")
+
+        replayPath = flag.String("replay", "", "path to a real trace file to replay instead of generating one")
+        format     = flag.String("format", "arc", "trace format for -replay: arc, twitter, or wiki")
+
         seedVal = flag.Int64("seed", time.Now().UnixNano(), "PRNG seed")
         outPath = flag.String("out", "", "output file (default stdout)")
     )
     flag.Parse()
 
-    rnd := rand.New(rand.NewSource(*seedVal))
-
-    var gen func() uint64
-    switch *dist {
-    case "uniform":
-        gen = rnd.Uint64
-    case "zipf":
-        if *zipfS <= 1.0 || *zipfV <= 0 {
-            fmt.Fprintln(os.Stderr, "zipfs must be >1 and zipfv >0")
-            os.Exit(1)
-        }
-        z := rand.NewZipf(rnd, *zipfS, *zipfV, ^uint64(0))
-        gen = z.Uint64
-    default:
-        fmt.Fprintln(os.Stderr, "unknown dist:", *dist)
-        os.Exit(1)
-    }
-
     var out *os.File
     var err error
     if *outPath == "" {
@@ -74,11 +81,42 @@ func main() {
         }
         defer out.Close()
     }
-
     w := bufio.NewWriterSize(out, 1<<20)
     defer w.Flush()
 
-    for i := 0; i < *n; i++ {
-        fmt.Fprintln(w, gen())
+    if *replayPath != "" {
+        if err := replay(w, *replayPath, *format); err != nil {
+            fmt.Fprintln(os.Stderr, "replay:", err)
+            os.Exit(1)
+        }
+        return
+    }
+
+    rnd := rand.New(rand.NewSource(*seedVal))
+    keyGen, err := newKeyGen(*dist, *n, *zipfS, *zipfV, *hotspotFrac, *hotspotOpFrac, rnd)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+
+    switch *mode {
+    case "keys":
+        for i := 0; i < *n; i++ {
+            fmt.Fprintln(w, keyGen())
+        }
+    case "workload":
+        cfg := workloadConfig{
+            readRatio:   *readRatio,
+            minSize:     *minSize,
+            maxSize:     *maxSize,
+            poissonRate: *poisson,
+        }
+        if err := runWorkload(w, *n, keyGen, cfg, rnd); err != nil {
+            fmt.Fprintln(os.Stderr, "workload:", err)
+            os.Exit(1)
+        }
+    default:
+        fmt.Fprintln(os.Stderr, "unknown mode:", *mode)
+        os.Exit(1)
     }
 }
@@ -0,0 +1,130 @@
+package main
+
+// distributions.go implements the key-generating distributions selectable
+// via -dist. uniform and zipf are the original two; scrambled-zipf, latest
+// and hotspot reproduce the access patterns the YCSB benchmark family is
+// known for, which plain Zipf cannot: hot-set churn, temporal locality, and
+// (for scrambled-zipf) avoiding the false contention that comes from popular
+// keys landing on numerically adjacent – and therefore often same-shard –
+// values.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "hash/fnv"
+    "math/rand"
+)
+
+// newKeyGen builds the uint64 generator used by both -mode=keys and
+// -mode=workload. n bounds the keyspace for every distribution except
+// uniform and zipf, which (as before) draw from the full uint64 range.
+func newKeyGen(dist string, n int, zipfS, zipfV, hotspotFrac, hotspotOpFrac float64, rnd *rand.Rand) (func() uint64, error) {
+    switch dist {
+    case "uniform":
+        return rnd.Uint64, nil
+
+    case "zipf":
+        if zipfS <= 1.0 || zipfV < 1.0 {
+            return nil, errDistParam("zipfs must be >1 and zipfv >=1")
+        }
+        z := rand.NewZipf(rnd, zipfS, zipfV, ^uint64(0))
+        return z.Uint64, nil
+
+    case "scrambled-zipf":
+        if zipfS <= 1.0 || zipfV < 1.0 {
+            return nil, errDistParam("zipfs must be >1 and zipfv >=1")
+        }
+        if n <= 0 {
+            return nil, errDistParam("n must be >0 for scrambled-zipf")
+        }
+        z := rand.NewZipf(rnd, zipfS, zipfV, uint64(n-1))
+        return scrambledZipfGen(z, uint64(n)), nil
+
+    case "latest":
+        if zipfS <= 1.0 || zipfV < 1.0 {
+            return nil, errDistParam("zipfs must be >1 and zipfv >=1")
+        }
+        if n <= 0 {
+            return nil, errDistParam("n must be >0 for latest")
+        }
+        return latestGen(rnd, zipfS, zipfV, n), nil
+
+    case "hotspot":
+        if n <= 0 {
+            return nil, errDistParam("n must be >0 for hotspot")
+        }
+        if hotspotFrac <= 0 || hotspotFrac >= 1 {
+            return nil, errDistParam("hotspot-frac must be in (0,1)")
+        }
+        if hotspotOpFrac <= 0 || hotspotOpFrac > 1 {
+            return nil, errDistParam("hotspot-op-frac must be in (0,1]")
+        }
+        return hotspotGen(rnd, n, hotspotFrac, hotspotOpFrac), nil
+
+    default:
+        return nil, errDistParam("unknown dist: " + dist)
+    }
+}
+
+type errDistParam string
+
+func (e errDistParam) Error() string { return string(e) }
+
+// scrambledZipfGen wraps z so that the popular low ranks it produces don't
+// translate into numerically adjacent (and therefore often same-shard) keys:
+// rank r is hashed with FNV-64 and reduced mod n, so the hot set is spread
+// uniformly across the keyspace instead of clustered at small integers.
+func scrambledZipfGen(z *rand.Zipf, n uint64) func() uint64 {
+    return func() uint64 {
+        r := z.Uint64()
+        h := fnv.New64a()
+        var buf [8]byte
+        for i := 0; i < 8; i++ {
+            buf[i] = byte(r >> (8 * i))
+        }
+        h.Write(buf[:])
+        return h.Sum64() % n
+    }
+}
+
+// latestGen reproduces YCSB's "latest" distribution: accesses skew toward
+// the most recently inserted keys. We model "insertion" as a monotonically
+// increasing counter (one tick per generated key, starting as if n keys
+// already exist) and pick how far back from the current tip to read via a
+// Zipf-distributed offset, so offset 0 (the single most recent key) is the
+// most likely outcome.
+func latestGen(rnd *rand.Rand, zipfS, zipfV float64, n int) func() uint64 {
+    offset := rand.NewZipf(rnd, zipfS, zipfV, uint64(n-1))
+    counter := int64(n)
+    return func() uint64 {
+        counter++
+        key := counter - int64(offset.Uint64())
+        if key < 0 {
+            key = 0
+        }
+        return uint64(key)
+    }
+}
+
+// hotspotGen splits the keyspace [0,n) into a hot prefix of size
+// hotspotFrac*n and a cold remainder, and sends hotspotOpFrac of requests to
+// a uniformly chosen hot key and the rest to a uniformly chosen cold key –
+// YCSB's "hotspot" distribution, useful for stressing scan resistance when
+// the cold traffic is a long sequential or semi-sequential sweep.
+func hotspotGen(rnd *rand.Rand, n int, hotspotFrac, hotspotOpFrac float64) func() uint64 {
+    hotSize := int(float64(n) * hotspotFrac)
+    if hotSize < 1 {
+        hotSize = 1
+    }
+    if hotSize >= n {
+        hotSize = n - 1
+    }
+    coldSize := n - hotSize
+
+    return func() uint64 {
+        if rnd.Float64() < hotspotOpFrac {
+            return uint64(rnd.Intn(hotSize))
+        }
+        return uint64(hotSize + rnd.Intn(coldSize))
+    }
+}
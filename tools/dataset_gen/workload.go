@@ -0,0 +1,69 @@
+package main
+
+// workload.go emits the two-phase read/write workload selected via
+// -mode=workload: each row is "op,key,size", optionally prefixed with a
+// Poisson-arrival t_ns column so downstream load-testers can honour
+// inter-arrival gaps instead of firing every request back-to-back.
+//
+// © 2025 arena-cache authors. MIT License.
+
+import (
+    "bufio"
+    "fmt"
+    "math"
+    "math/rand"
+)
+
+// workloadConfig bundles the -mode=workload knobs.
+type workloadConfig struct {
+    readRatio   float64
+    minSize     int
+    maxSize     int
+    poissonRate float64 // events/sec; 0 disables the t_ns column
+}
+
+// runWorkload writes n rows to w using keyGen for key selection.
+func runWorkload(w *bufio.Writer, n int, keyGen func() uint64, cfg workloadConfig, rnd *rand.Rand) error {
+    if cfg.maxSize < cfg.minSize {
+        return errDistParam("max-size must be >= min-size")
+    }
+    sizeSpan := cfg.maxSize - cfg.minSize + 1
+
+    withTiming := cfg.poissonRate > 0
+    if withTiming {
+        fmt.Fprintln(w, "t_ns,op,key,size")
+    } else {
+        fmt.Fprintln(w, "op,key,size")
+    }
+
+    var tNanos int64
+    for i := 0; i < n; i++ {
+        op := "get"
+        if rnd.Float64() >= cfg.readRatio {
+            op = "set"
+        }
+        key := keyGen()
+        size := cfg.minSize + rnd.Intn(sizeSpan)
+
+        if withTiming {
+            tNanos += poissonInterArrivalNanos(rnd, cfg.poissonRate)
+            if _, err := fmt.Fprintf(w, "%d,%s,%d,%d\n", tNanos, op, key, size); err != nil {
+                return err
+            }
+            continue
+        }
+        if _, err := fmt.Fprintf(w, "%s,%d,%d\n", op, key, size); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// poissonInterArrivalNanos samples the gap, in nanoseconds, until the next
+// event of a Poisson process with the given mean rate (events/sec): Poisson
+// arrivals have exponentially distributed inter-arrival times, drawn here by
+// inverse-transform sampling.
+func poissonInterArrivalNanos(rnd *rand.Rand, rate float64) int64 {
+    gapSeconds := -math.Log(1-rnd.Float64()) / rate
+    return int64(gapSeconds * 1e9)
+}